@@ -37,6 +37,7 @@ var AllowErrors = flag.Bool("allow-errors", false, "If true, don't fail on error
 var WorkDir = flag.String("work-dir", "", "Working directory for the generator.")
 var UseTags = flag.Bool("use-tags", false, "If true, use the openapi tags instead of the config yaml.")
 var KubernetesRelease = flag.String("kubernetes-release", "", "Kubernetes release version.")
+var CRDDir = flag.String("crd-dir", "", "Directory of CustomResourceDefinition YAML manifests to document along with the built-in APIs.")
 
 // titleCase converts a string to title case as a replacement for deprecated strings.Title
 func titleCase(s string) string {
@@ -79,53 +80,56 @@ func NewConfig() (*Config, error) {
 	k8sRelease := fmt.Sprintf("v%s", strings.ReplaceAll(*KubernetesRelease, ".", "_"))
 	VersionedConfigDir = filepath.Join(ConfigDir, k8sRelease)
 
-	config, err := loadAndInitializeConfig()
+	config, specs, err := loadAndInitializeConfig()
 	if err != nil {
 		return nil, err
 	}
 
-	if err := processDefinitionsAndOperations(config); err != nil {
+	if err := processDefinitionsAndOperations(config, specs); err != nil {
 		return nil, err
 	}
 
 	return config, nil
 }
 
-// loadAndInitializeConfig loads configuration and specs, then initializes basic config
-func loadAndInitializeConfig() (*Config, error) {
+// loadAndInitializeConfig loads configuration and specs, then initializes basic config.
+// The specs, including the ones converted from the CustomResourceDefinitions, are returned
+// for further processing.
+func loadAndInitializeConfig() (*Config, []*loads.Document, error) {
 	config, err := LoadConfigFromYAML()
 	if err != nil {
-		return nil, fmt.Errorf("failed to load config yaml: %w", err)
+		return nil, nil, fmt.Errorf("failed to load config yaml: %w", err)
 	}
 
 	specs, err := LoadOpenApiSpec()
 	if err != nil {
-		return nil, fmt.Errorf("failed to load openapi spec: %w", err)
+		return nil, nil, fmt.Errorf("failed to load openapi spec: %w", err)
 	}
 
 	// Parse spec version
 	ParseSpecInfo(specs, config)
 
+	crdSpecs, err := config.LoadCustomResourceDefinitionSpecs()
+	if err != nil {
+		return nil, nil, err
+	}
+	specs = append(specs, crdSpecs...)
+
 	// Set the spec version
 	config.SpecVersion = fmt.Sprintf("v%s.%s", *KubernetesRelease, "0")
 
 	// Initialize all of the operations
 	defs, err := NewDefinitions(config, specs)
 	if err != nil {
-		return nil, fmt.Errorf("failed to init definitions: %w", err)
+		return nil, nil, fmt.Errorf("failed to init definitions: %w", err)
 	}
 	config.Definitions = *defs
 
-	return config, nil
+	return config, specs, nil
 }
 
 // processDefinitionsAndOperations handles the main processing logic
-func processDefinitionsAndOperations(config *Config) error {
-	specs, err := LoadOpenApiSpec()
-	if err != nil {
-		return fmt.Errorf("failed to load openapi spec: %w", err)
-	}
-
+func processDefinitionsAndOperations(config *Config, specs []*loads.Document) error {
 	if *UseTags {
 		// Initialize the config and ToC from the tags on definitions
 		if err := config.genConfigFromTags(specs); err != nil {
@@ -245,7 +249,7 @@ func (config *Config) initOperationsFromTags(specs []*loads.Document) error {
 			op := operation
 			o := &op
 			config.Operations[operation.ID] = o
-			group, version, kind, sub := o.GetGroupVersionKindSub()
+			group, version, kind, sub := o.GetGroupVersionKindSub(config)
 			if sub == "status" {
 				return
 			}
@@ -524,13 +528,17 @@ func (c *Config) initOperationParameters(specs []*loads.Document) error {
 				param = p
 			}
 
+			field, err := s.parameterToField(param)
+			if err != nil {
+				return fmt.Errorf("operation %s: %w", op.ID, err)
+			}
 			switch location {
 			case "path":
-				op.PathParams = append(op.PathParams, s.parameterToField(param))
+				op.PathParams = append(op.PathParams, field)
 			case "query":
-				op.QueryParams = append(op.QueryParams, s.parameterToField(param))
+				op.QueryParams = append(op.QueryParams, field)
 			case "body":
-				op.BodyParams = append(op.BodyParams, s.parameterToField(param))
+				op.BodyParams = append(op.BodyParams, field)
 			default:
 				return fmt.Errorf("unknown location %q", location)
 			}
@@ -550,13 +558,17 @@ func (c *Config) initOperationParameters(specs []*loads.Document) error {
 				param = p
 			}
 
+			field, err := s.parameterToField(param)
+			if err != nil {
+				return fmt.Errorf("operation %s: %w", op.ID, err)
+			}
 			switch location {
 			case "path":
-				op.PathParams = append(op.PathParams, s.parameterToField(param))
+				op.PathParams = append(op.PathParams, field)
 			case "query":
-				op.QueryParams = append(op.QueryParams, s.parameterToField(param))
+				op.QueryParams = append(op.QueryParams, field)
 			case "body":
-				op.BodyParams = append(op.BodyParams, s.parameterToField(param))
+				op.BodyParams = append(op.BodyParams, field)
 			default:
 				return fmt.Errorf("unknown location %q", location)
 			}
@@ -566,10 +578,14 @@ func (c *Config) initOperationParameters(specs []*loads.Document) error {
 			if response.Schema == nil {
 				continue
 			}
+			typeName, err := GetTypeName(*response.Schema)
+			if err != nil {
+				return fmt.Errorf("operation %s response %d: %w", op.ID, code, err)
+			}
 			r := &HttpResponse{
 				Field: Field{
 					Description: strings.ReplaceAll(response.Description, "\n", " "),
-					Type:        GetTypeName(*response.Schema),
+					Type:        typeName,
 					Name:        fmt.Sprintf("%d", code),
 				},
 				Code: fmt.Sprintf("%d", code),
@@ -0,0 +1,580 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/go-openapi/loads"
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+// The CustomResourceDefinitions are converted to Swagger 2.0 documents, the
+// same way as the OpenAPI v3 documents, so that they are merged into the same
+// Definitions and Operations as the built-in APIs:
+//
+//   - The schema of each served version becomes a definition, with a list
+//     definition, and with a definition for each nested object, named after
+//     the kind and the path of the property, e.g. CronTabSpec.
+//   - The operations are the ones the API server provides for custom
+//     resources, with the same IDs, e.g. createStableExampleComV1NamespacedCronTab.
+//
+// The group of a custom resource is shortened to its first label, the same
+// as the built-in groups, e.g. 'stable' for 'stable.example.com'. If the first
+// label is the short name of another group already, e.g. 'networking' for
+// 'networking.istio.io', the full name with the dots replaced by dashes is
+// used instead, e.g. 'networking-istio-io'. This is the group used in the
+// resource categories of the config. The full name of the group and the group
+// name in the operation IDs are added to the config if they are not found.
+
+const (
+	objectMetaRef   = "#/definitions/io.k8s.apimachinery.pkg.apis.meta.v1.ObjectMeta"
+	listMetaRef     = "#/definitions/io.k8s.apimachinery.pkg.apis.meta.v1.ListMeta"
+	statusRef       = "#/definitions/io.k8s.apimachinery.pkg.apis.meta.v1.Status"
+	deleteOptsRef   = "#/definitions/io.k8s.apimachinery.pkg.apis.meta.v1.DeleteOptions"
+	patchRef        = "#/definitions/io.k8s.apimachinery.pkg.apis.meta.v1.Patch"
+	watchEventRef   = "#/definitions/io.k8s.apimachinery.pkg.apis.meta.v1.WatchEvent"
+	intOrStringRef  = "#/definitions/io.k8s.apimachinery.pkg.util.intstr.IntOrString"
+	crdAPIVersion   = "apiextensions.k8s.io/v1"
+	crdKind         = "CustomResourceDefinition"
+	namespacedScope = "Namespaced"
+)
+
+type customResourceDefinition struct {
+	APIVersion string `yaml:"apiVersion"`
+	Kind       string `yaml:"kind"`
+	Metadata   struct {
+		Name string `yaml:"name"`
+	} `yaml:"metadata"`
+	Spec struct {
+		Group string `yaml:"group"`
+		Names struct {
+			Kind     string `yaml:"kind"`
+			ListKind string `yaml:"listKind"`
+			Plural   string `yaml:"plural"`
+		} `yaml:"names"`
+		Scope    string       `yaml:"scope"`
+		Versions []crdVersion `yaml:"versions"`
+	} `yaml:"spec"`
+}
+
+type crdVersion struct {
+	Name   string `yaml:"name"`
+	Served bool   `yaml:"served"`
+	Schema struct {
+		OpenAPIV3Schema map[string]interface{} `yaml:"openAPIV3Schema"`
+	} `yaml:"schema"`
+	Subresources map[string]interface{} `yaml:"subresources"`
+}
+
+// loadCustomResourceDefinitions reads the CustomResourceDefinitions from the
+// YAML files in a directory. A file can hold several documents, the ones
+// that are not apiextensions.k8s.io/v1 CustomResourceDefinitions are skipped.
+func loadCustomResourceDefinitions(dir string) ([]*customResourceDefinition, error) {
+	crds := []*customResourceDefinition{}
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		ext := filepath.Ext(path)
+		if ext != ".yaml" && ext != ".yml" {
+			return nil
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		decoder := yamlv3.NewDecoder(f)
+		for {
+			crd := &customResourceDefinition{}
+			if err := decoder.Decode(crd); errors.Is(err, io.EOF) {
+				break
+			} else if err != nil {
+				return fmt.Errorf("could not parse yaml file %s: %w", path, err)
+			}
+			if crd.Kind != crdKind {
+				continue
+			}
+			if crd.APIVersion != crdAPIVersion {
+				fmt.Printf("\033[31mWarning: skipping %s %s in %s, only %s is supported\033[0m\n",
+					crd.APIVersion, crd.Metadata.Name, path, crdAPIVersion)
+				continue
+			}
+			crds = append(crds, crd)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return crds, nil
+}
+
+// LoadCustomResourceDefinitionSpecs converts the CustomResourceDefinitions
+// found in the CRD directory, if any, to open-api documents.
+func (c *Config) LoadCustomResourceDefinitionSpecs() ([]*loads.Document, error) {
+	if *CRDDir == "" {
+		return nil, nil
+	}
+
+	crds, err := loadCustomResourceDefinitions(*CRDDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load CustomResourceDefinitions: %w", err)
+	}
+
+	docs := []*loads.Document{}
+	for _, crd := range crds {
+		group, err := c.registerCustomResourceGroup(crd.Spec.Group)
+		if err != nil {
+			return nil, fmt.Errorf("CustomResourceDefinition %s: %w", crd.Metadata.Name, err)
+		}
+		d, err := crd.toSpec(group, c.OperationGroupMap[group])
+		if err != nil {
+			return nil, fmt.Errorf("CustomResourceDefinition %s: %w", crd.Metadata.Name, err)
+		}
+		docs = append(docs, d)
+	}
+
+	return docs, nil
+}
+
+// registerCustomResourceGroup adds the full name of a custom resource group
+// and its name in the operation IDs to the config, and returns the short
+// group name.
+func (c *Config) registerCustomResourceGroup(fullGroup string) (string, error) {
+	if fullGroup == "" {
+		return "", fmt.Errorf("no group specified")
+	}
+
+	if c.GroupFullNames == nil {
+		c.GroupFullNames = map[string]string{}
+	}
+	group := ""
+	candidates := []string{strings.Split(fullGroup, ".")[0], strings.ReplaceAll(fullGroup, ".", "-")}
+	for _, short := range candidates {
+		if name, ok := c.GroupFullNames[short]; !ok || name == fullGroup {
+			group = short
+			break
+		}
+	}
+	if group == "" {
+		return "", fmt.Errorf("group %s cannot be shortened to %q or %q, which are the short names of other groups already",
+			fullGroup, candidates[0], candidates[1])
+	}
+	c.GroupFullNames[group] = fullGroup
+	if c.customResourceGroups == nil {
+		c.customResourceGroups = map[string]string{}
+	}
+	c.customResourceGroups[fullGroup] = group
+
+	if c.OperationGroupMap == nil {
+		c.OperationGroupMap = map[string]string{}
+	}
+	if _, ok := c.OperationGroupMap[group]; !ok {
+		// e.g. StableExampleCom for stable.example.com
+		c.OperationGroupMap[group] = strings.ReplaceAll(titleCase(strings.ReplaceAll(fullGroup, ".", " ")), " ", "")
+	}
+
+	return group, nil
+}
+
+// crdDefinitionName returns the name of a definition in a custom resource
+// group with the given short name, in a format understood by GuessGVK, e.g.
+// 'com.example.api.stable.v1.CronTab' for the 'stable.example.com' group.
+func crdDefinitionName(fullGroup, group, version, kind string) string {
+	labels := strings.Split(fullGroup, ".")
+	parts := []string{}
+	for i := len(labels) - 1; i > 0; i-- {
+		parts = append(parts, labels[i])
+	}
+	parts = append(parts, "api", group, version, kind)
+	return strings.Join(parts, ".")
+}
+
+// toSpec converts the CustomResourceDefinition to a Swagger 2.0 document.
+func (crd *customResourceDefinition) toSpec(group, opGroup string) (*loads.Document, error) {
+	names := crd.Spec.Names
+	if names.Kind == "" || names.Plural == "" {
+		return nil, fmt.Errorf("kind and plural names must be specified")
+	}
+	listKind := names.ListKind
+	if listKind == "" {
+		listKind = names.Kind + "List"
+	}
+
+	definitions := map[string]interface{}{}
+	paths := map[string]interface{}{}
+	for _, v := range crd.Spec.Versions {
+		if !v.Served {
+			continue
+		}
+		if v.Schema.OpenAPIV3Schema == nil {
+			return nil, fmt.Errorf("no schema found for version %s", v.Name)
+		}
+
+		prefix := crdDefinitionName(crd.Spec.Group, group, v.Name, "")
+		gvk := func(kind string) []interface{} {
+			return []interface{}{map[string]interface{}{
+				"group":   crd.Spec.Group,
+				"version": v.Name,
+				"kind":    kind,
+			}}
+		}
+
+		schema := hoistSchema(v.Schema.OpenAPIV3Schema, names.Kind, prefix, definitions)
+		props, _ := schema["properties"].(map[string]interface{})
+		if props == nil {
+			props = map[string]interface{}{}
+			schema["properties"] = props
+		}
+		addStandardProperties(props, objectMetaRef)
+		schema["x-kubernetes-group-version-kind"] = gvk(names.Kind)
+		schema[resourceNameKey] = names.Plural
+		definitions[prefix+names.Kind] = schema
+
+		list := map[string]interface{}{
+			"description": fmt.Sprintf("%s is a list of %s", listKind, names.Kind),
+			"type":        "object",
+			"required":    []interface{}{"items"},
+			"properties": map[string]interface{}{
+				"items": map[string]interface{}{
+					"description": fmt.Sprintf("List of %s. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md", names.Plural),
+					"type":        "array",
+					"items":       map[string]interface{}{"$ref": v2SchemaPrefix + prefix + names.Kind},
+				},
+			},
+			"x-kubernetes-group-version-kind": gvk(listKind),
+		}
+		addStandardProperties(list["properties"].(map[string]interface{}), listMetaRef)
+		definitions[prefix+listKind] = list
+
+		_, hasStatus := v.Subresources["status"]
+		crd.addPaths(paths, group, opGroup, v.Name, listKind, hasStatus)
+	}
+
+	swagger := map[string]interface{}{
+		"swagger":     "2.0",
+		"info":        map[string]interface{}{"title": crd.Metadata.Name, "version": "unversioned"},
+		"paths":       paths,
+		"definitions": definitions,
+	}
+	raw, err := json.Marshal(swagger)
+	if err != nil {
+		return nil, err
+	}
+	return loads.Analyzed(json.RawMessage(raw), "2.0")
+}
+
+// addStandardProperties adds the apiVersion, kind and metadata properties
+// that are usually left out of the CRD schemas, or not fully described.
+func addStandardProperties(props map[string]interface{}, metaRef string) {
+	if _, ok := props["apiVersion"]; !ok {
+		props["apiVersion"] = map[string]interface{}{
+			"description": "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+			"type":        "string",
+		}
+	}
+	if _, ok := props["kind"]; !ok {
+		props["kind"] = map[string]interface{}{
+			"description": "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+			"type":        "string",
+		}
+	}
+	props["metadata"] = map[string]interface{}{
+		"description": "Standard object's metadata. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#metadata",
+		"$ref":        metaRef,
+	}
+}
+
+// hoistSchema returns a copy of a schema where the nested objects with
+// properties are replaced with references to new definitions, named after
+// the parent and the property. The schemas marked with
+// 'x-kubernetes-int-or-string' are replaced with references to the
+// IntOrString definition, and the other schemas without a type are given
+// one.
+func hoistSchema(schema map[string]interface{}, name, prefix string, definitions map[string]interface{}) map[string]interface{} {
+	if b, _ := schema["x-kubernetes-int-or-string"].(bool); b && schema["$ref"] == nil {
+		ref := map[string]interface{}{"$ref": intOrStringRef}
+		if desc, ok := schema["description"]; ok {
+			ref["description"] = desc
+		}
+		return ref
+	}
+
+	result := make(map[string]interface{}, len(schema))
+	for k, v := range schema {
+		result[k] = v
+	}
+
+	if _, ok := result["type"]; !ok && result["$ref"] == nil && !typedAlternatives(result) {
+		result["type"] = "object"
+	}
+
+	// hoist replaces a nested object with a reference to a new definition
+	hoist := func(v interface{}, childName string) interface{} {
+		child, ok := v.(map[string]interface{})
+		if !ok {
+			return v
+		}
+		if _, ok := child["properties"]; !ok {
+			return hoistSchema(child, childName, prefix, definitions)
+		}
+		definitions[prefix+childName] = hoistSchema(child, childName, prefix, definitions)
+		ref := map[string]interface{}{"$ref": v2SchemaPrefix + prefix + childName}
+		if desc, ok := child["description"]; ok {
+			ref["description"] = desc
+		}
+		return ref
+	}
+
+	if props, ok := schema["properties"].(map[string]interface{}); ok {
+		// sorted for stable definition names when two properties collide
+		keys := make([]string, 0, len(props))
+		for k := range props {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		hoisted := make(map[string]interface{}, len(props))
+		for _, k := range keys {
+			childName := name + titleCase(k)
+			p, _ := props[k].(map[string]interface{})
+			if items, ok := p["items"].(map[string]interface{}); ok && p["type"] == "array" {
+				array := make(map[string]interface{}, len(p))
+				for pk, pv := range p {
+					array[pk] = pv
+				}
+				array["items"] = hoist(items, childName)
+				hoisted[k] = array
+				continue
+			}
+			if additional, ok := p["additionalProperties"].(map[string]interface{}); ok {
+				m := make(map[string]interface{}, len(p))
+				for pk, pv := range p {
+					m[pk] = pv
+				}
+				m["additionalProperties"] = hoist(additional, childName)
+				hoisted[k] = m
+				continue
+			}
+			hoisted[k] = hoist(props[k], childName)
+		}
+		result["properties"] = hoisted
+	}
+
+	return result
+}
+
+// typedAlternatives returns true if the schema lists oneOf or anyOf alternatives that
+// all carry a type or a reference, so that the type can be derived from them.
+// Alternatives that only add validation (e.g. required fields) do not count.
+func typedAlternatives(schema map[string]interface{}) bool {
+	for _, key := range []string{"oneOf", "anyOf"} {
+		alternatives, ok := schema[key].([]interface{})
+		if !ok || len(alternatives) == 0 {
+			continue
+		}
+		for _, a := range alternatives {
+			m, _ := a.(map[string]interface{})
+			if m["type"] == nil && m["$ref"] == nil && !typedAlternatives(m) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// crdParam returns a query or path parameter.
+func crdParam(in, name, typ, description string) map[string]interface{} {
+	p := map[string]interface{}{
+		"in":          in,
+		"name":        name,
+		"type":        typ,
+		"description": description,
+		"uniqueItems": true,
+	}
+	if in == "path" {
+		p["required"] = true
+	}
+	return p
+}
+
+// crdBody returns the body parameter.
+func crdBody(ref string) map[string]interface{} {
+	return map[string]interface{}{
+		"in":       "body",
+		"name":     "body",
+		"required": true,
+		"schema":   map[string]interface{}{"$ref": ref},
+	}
+}
+
+var (
+	crdListParams = []interface{}{
+		crdParam("query", "allowWatchBookmarks", "boolean", "allowWatchBookmarks requests watch events with type \"BOOKMARK\". Servers that do not implement bookmarks may ignore this flag and bookmarks are sent at the server's discretion. Clients should not assume bookmarks are returned at any specific interval, nor may they assume the server will send any BOOKMARK event during a session. If this is not a watch, this field is ignored."),
+		crdParam("query", "continue", "string", "The continue option should be set when retrieving more results from the server. Since this value is server defined, clients may only use the continue value from a previous query result with identical query parameters (except for the value of continue) and the server may reject a continue value it does not recognize."),
+		crdParam("query", "fieldSelector", "string", "A selector to restrict the list of returned objects by their fields. Defaults to everything."),
+		crdParam("query", "labelSelector", "string", "A selector to restrict the list of returned objects by their labels. Defaults to everything."),
+		crdParam("query", "limit", "integer", "limit is a maximum number of responses to return for a list call. If more items exist, the server will set the `continue` field on the list metadata to a value that can be used with the same initial query to retrieve the next set of results."),
+		crdParam("query", "resourceVersion", "string", "resourceVersion sets a constraint on what resource versions a request may be served from. See https://kubernetes.io/docs/reference/using-api/api-concepts/#resource-versions for details. Defaults to unset"),
+		crdParam("query", "timeoutSeconds", "integer", "Timeout for the list/watch call. This limits the duration of the call, regardless of any activity or inactivity."),
+		crdParam("query", "watch", "boolean", "Watch for changes to the described resources and return them as a stream of add, update, and remove notifications. Specify resourceVersion."),
+	}
+	crdDryRunParam       = crdParam("query", "dryRun", "string", "When present, indicates that modifications should not be persisted. An invalid or unrecognized dryRun directive will result in an error response and no further processing of the request. Valid values are: - All: all dry run stages will be processed")
+	crdFieldManagerParam = crdParam("query", "fieldManager", "string", "fieldManager is a name associated with the actor or entity that is making these changes. The value must be less than or 128 characters long, and only contain printable characters, as defined by https://golang.org/pkg/unicode/#IsPrint.")
+	crdForceParam        = crdParam("query", "force", "boolean", "Force is going to \"force\" Apply requests. It means user will re-acquire conflicting fields owned by other people. Force flag must be unset for non-apply patch requests.")
+	crdDeleteParams      = []interface{}{
+		crdDryRunParam,
+		crdParam("query", "gracePeriodSeconds", "integer", "The duration in seconds before the object should be deleted. Value must be non-negative integer. The value zero indicates delete immediately. If this value is nil, the default grace period for the specified type will be used. Defaults to a per object value if not specified. zero means delete immediately."),
+		crdParam("query", "propagationPolicy", "string", "Whether and how garbage collection will be performed. Either this field or OrphanDependents may be set, but not both. The default policy is decided by the existing finalizer set in the metadata.finalizers and the resource-specific default policy. Acceptable values are: 'Orphan' - orphan the dependents; 'Background' - allow the garbage collector to delete the dependents in the background; 'Foreground' - a cascading policy that deletes all dependents in the foreground."),
+		crdBody(deleteOptsRef),
+	}
+)
+
+// crdResponses returns the responses with the codes in the list.
+func crdResponses(ref string, codes ...string) map[string]interface{} {
+	descriptions := map[string]string{"200": "OK", "201": "Created", "202": "Accepted"}
+	responses := map[string]interface{}{
+		"401": map[string]interface{}{"description": "Unauthorized"},
+	}
+	for _, code := range codes {
+		responses[code] = map[string]interface{}{
+			"description": descriptions[code],
+			"schema":      map[string]interface{}{"$ref": ref},
+		}
+	}
+	return responses
+}
+
+// addPaths adds the paths and the operations of a version of the custom
+// resource.
+func (crd *customResourceDefinition) addPaths(paths map[string]interface{}, group, opGroup, version, listKind string, hasStatus bool) {
+	kind := crd.Spec.Names.Kind
+	plural := crd.Spec.Names.Plural
+	namespaced := crd.Spec.Scope == namespacedScope
+	kindRef := v2SchemaPrefix + crdDefinitionName(crd.Spec.Group, group, version, kind)
+	listRef := v2SchemaPrefix + crdDefinitionName(crd.Spec.Group, group, version, listKind)
+
+	ver := []rune(version)
+	ver[0] = []rune(strings.ToUpper(string(ver[0])))[0]
+	gv := opGroup + string(ver)
+
+	ns := ""
+	root := "/apis/" + crd.Spec.Group + "/" + version
+	base := root
+	pathParams := []interface{}{}
+	if namespaced {
+		ns = namespacedScope
+		base = root + "/namespaces/{namespace}"
+		pathParams = append(pathParams, crdParam("path", "namespace", "string", "object name and auth scope, such as for teams and projects"))
+	}
+	pathParams = append(pathParams, crdParam("query", "pretty", "string", "If 'true', then the output is pretty printed."))
+	nameParam := crdParam("path", "name", "string", "name of the "+kind)
+
+	operation := func(action, id, description string, params []interface{}, responses map[string]interface{}) map[string]interface{} {
+		return map[string]interface{}{
+			"operationId":                     id,
+			"description":                     description,
+			"parameters":                      params,
+			"responses":                       responses,
+			"consumes":                        []interface{}{"*/*"},
+			"produces":                        []interface{}{"application/json", "application/yaml"},
+			"x-kubernetes-action":             action,
+			"x-kubernetes-group-version-kind": map[string]interface{}{"group": crd.Spec.Group, "version": version, "kind": kind},
+		}
+	}
+	withName := func(params []interface{}) []interface{} {
+		return append([]interface{}{nameParam}, params...)
+	}
+
+	paths[base+"/"+plural] = map[string]interface{}{
+		"parameters": pathParams,
+		"get": operation("list", "list"+gv+ns+kind,
+			"list objects of kind "+kind, crdListParams, crdResponses(listRef, "200")),
+		"post": operation("post", "create"+gv+ns+kind,
+			"create a "+kind, []interface{}{crdBody(kindRef), crdDryRunParam, crdFieldManagerParam},
+			crdResponses(kindRef, "200", "201", "202")),
+		"delete": operation("deletecollection", "delete"+gv+"Collection"+ns+kind,
+			"delete collection of "+kind, append(append([]interface{}{}, crdListParams...), crdDeleteParams...),
+			crdResponses(statusRef, "200")),
+	}
+	paths[base+"/"+plural+"/{name}"] = map[string]interface{}{
+		"parameters": withName(pathParams),
+		"get": operation("get", "read"+gv+ns+kind,
+			"read the specified "+kind, nil, crdResponses(kindRef, "200")),
+		"put": operation("put", "replace"+gv+ns+kind,
+			"replace the specified "+kind, []interface{}{crdBody(kindRef), crdDryRunParam, crdFieldManagerParam},
+			crdResponses(kindRef, "200", "201")),
+		"patch": operation("patch", "patch"+gv+ns+kind,
+			"partially update the specified "+kind, []interface{}{crdBody(patchRef), crdDryRunParam, crdFieldManagerParam, crdForceParam},
+			crdResponses(kindRef, "200", "201")),
+		"delete": operation("delete", "delete"+gv+ns+kind,
+			"delete a "+kind, crdDeleteParams, crdResponses(statusRef, "200", "202")),
+	}
+	paths[root+"/watch"+strings.TrimPrefix(base, root)+"/"+plural] = map[string]interface{}{
+		"parameters": pathParams,
+		"get": operation("watchlist", "watch"+gv+ns+kind+"List",
+			"watch individual changes to a list of "+kind+". deprecated: use the 'watch' parameter with a list operation instead.",
+			crdListParams, crdResponses(watchEventRef, "200")),
+	}
+	paths[root+"/watch"+strings.TrimPrefix(base, root)+"/"+plural+"/{name}"] = map[string]interface{}{
+		"parameters": withName(pathParams),
+		"get": operation("watch", "watch"+gv+ns+kind,
+			"watch changes to an object of kind "+kind+". deprecated: use the 'watch' parameter with a list operation instead, filtered to a single item with the 'fieldSelector' parameter.",
+			crdListParams, crdResponses(watchEventRef, "200")),
+	}
+
+	if namespaced {
+		allParams := []interface{}{crdParam("query", "pretty", "string", "If 'true', then the output is pretty printed.")}
+		paths[root+"/"+plural] = map[string]interface{}{
+			"parameters": allParams,
+			"get": operation("list", "list"+gv+kind+"ForAllNamespaces",
+				"list objects of kind "+kind, crdListParams, crdResponses(listRef, "200")),
+		}
+		paths[root+"/watch/"+plural] = map[string]interface{}{
+			"parameters": allParams,
+			"get": operation("watchlist", "watch"+gv+kind+"ListForAllNamespaces",
+				"watch individual changes to a list of "+kind+". deprecated: use the 'watch' parameter with a list operation instead.",
+				crdListParams, crdResponses(watchEventRef, "200")),
+		}
+	}
+
+	if hasStatus {
+		paths[base+"/"+plural+"/{name}/status"] = map[string]interface{}{
+			"parameters": withName(pathParams),
+			"get": operation("get", "read"+gv+ns+kind+"Status",
+				"read status of the specified "+kind, nil, crdResponses(kindRef, "200")),
+			"put": operation("put", "replace"+gv+ns+kind+"Status",
+				"replace status of the specified "+kind, []interface{}{crdBody(kindRef), crdDryRunParam, crdFieldManagerParam},
+				crdResponses(kindRef, "200", "201")),
+			"patch": operation("patch", "patch"+gv+ns+kind+"Status",
+				"partially update status of the specified "+kind, []interface{}{crdBody(patchRef), crdDryRunParam, crdFieldManagerParam, crdForceParam},
+				crdResponses(kindRef, "200", "201")),
+		}
+	}
+}
@@ -0,0 +1,462 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+const testPrefix = "com.example.api.stable.v1."
+
+// parseSchema parses a schema written in YAML.
+func parseSchema(t *testing.T, s string) map[string]interface{} {
+	t.Helper()
+	schema := map[string]interface{}{}
+	if err := yamlv3.Unmarshal([]byte(s), &schema); err != nil {
+		t.Fatalf("could not parse schema: %v", err)
+	}
+	return schema
+}
+
+func TestHoistSchema(t *testing.T) {
+	tests := []struct {
+		Name        string
+		Schema      string
+		Expected    string
+		Definitions map[string]string
+	}{
+		{
+			Name: "nested object",
+			Schema: `
+type: object
+properties:
+  spec:
+    description: The spec.
+    type: object
+    properties:
+      image:
+        type: string
+`,
+			Expected: `
+type: object
+properties:
+  spec:
+    description: The spec.
+    $ref: "#/definitions/com.example.api.stable.v1.CronTabSpec"
+`,
+			Definitions: map[string]string{
+				"CronTabSpec": `
+description: The spec.
+type: object
+properties:
+  image:
+    type: string
+`,
+			},
+		},
+		{
+			Name: "array of objects",
+			Schema: `
+type: object
+properties:
+  ports:
+    type: array
+    items:
+      type: object
+      properties:
+        port:
+          type: integer
+`,
+			Expected: `
+type: object
+properties:
+  ports:
+    type: array
+    items:
+      $ref: "#/definitions/com.example.api.stable.v1.CronTabPorts"
+`,
+			Definitions: map[string]string{
+				"CronTabPorts": `
+type: object
+properties:
+  port:
+    type: integer
+`,
+			},
+		},
+		{
+			Name: "map of objects",
+			Schema: `
+type: object
+properties:
+  labels:
+    type: object
+    additionalProperties:
+      type: object
+      properties:
+        value:
+          type: string
+`,
+			Expected: `
+type: object
+properties:
+  labels:
+    type: object
+    additionalProperties:
+      $ref: "#/definitions/com.example.api.stable.v1.CronTabLabels"
+`,
+			Definitions: map[string]string{
+				"CronTabLabels": `
+type: object
+properties:
+  value:
+    type: string
+`,
+			},
+		},
+		{
+			Name: "int or string",
+			Schema: `
+type: object
+properties:
+  port:
+    description: The port.
+    x-kubernetes-int-or-string: true
+    anyOf:
+    - type: integer
+    - type: string
+`,
+			Expected: `
+type: object
+properties:
+  port:
+    description: The port.
+    $ref: "#/definitions/io.k8s.apimachinery.pkg.util.intstr.IntOrString"
+`,
+		},
+		{
+			Name: "no type",
+			Schema: `
+properties:
+  config:
+    x-kubernetes-preserve-unknown-fields: true
+`,
+			Expected: `
+type: object
+properties:
+  config:
+    type: object
+    x-kubernetes-preserve-unknown-fields: true
+`,
+		},
+		{
+			Name: "typed alternatives",
+			Schema: `
+type: object
+properties:
+  value:
+    anyOf:
+    - type: integer
+    - type: boolean
+  other:
+    oneOf:
+    - type: string
+    - type: number
+`,
+			Expected: `
+type: object
+properties:
+  value:
+    anyOf:
+    - type: integer
+    - type: boolean
+  other:
+    oneOf:
+    - type: string
+    - type: number
+`,
+		},
+		{
+			Name: "validation only alternatives",
+			Schema: `
+type: object
+properties:
+  source:
+    anyOf:
+    - required: [url]
+    - required: [path]
+`,
+			Expected: `
+type: object
+properties:
+  source:
+    type: object
+    anyOf:
+    - required: [url]
+    - required: [path]
+`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			definitions := map[string]interface{}{}
+			actual := hoistSchema(parseSchema(t, test.Schema), "CronTab", testPrefix, definitions)
+			if expected := parseSchema(t, test.Expected); !reflect.DeepEqual(actual, expected) {
+				t.Errorf("got %v, expected %v", actual, expected)
+			}
+			if len(definitions) != len(test.Definitions) {
+				t.Errorf("got %d definitions, expected %d", len(definitions), len(test.Definitions))
+			}
+			for name, s := range test.Definitions {
+				if expected := parseSchema(t, s); !reflect.DeepEqual(definitions[testPrefix+name], expected) {
+					t.Errorf("definition %s: got %v, expected %v", name, definitions[testPrefix+name], expected)
+				}
+			}
+		})
+	}
+}
+
+func TestRegisterCustomResourceGroup(t *testing.T) {
+	tests := []struct {
+		Name          string
+		Group         string
+		FullNames     map[string]string
+		Expected      string
+		ExpectedOpGrp string
+		Error         bool
+	}{
+		{
+			Name:          "first label",
+			Group:         "stable.example.com",
+			Expected:      "stable",
+			ExpectedOpGrp: "StableExampleCom",
+		},
+		{
+			Name:          "registered already",
+			Group:         "stable.example.com",
+			FullNames:     map[string]string{"stable": "stable.example.com"},
+			Expected:      "stable",
+			ExpectedOpGrp: "StableExampleCom",
+		},
+		{
+			Name:          "clashing with a built-in group",
+			Group:         "networking.istio.io",
+			FullNames:     map[string]string{"networking": "networking.k8s.io"},
+			Expected:      "networking-istio-io",
+			ExpectedOpGrp: "NetworkingIstioIo",
+		},
+		{
+			Name:  "clashing with both names",
+			Group: "networking.istio.io",
+			FullNames: map[string]string{
+				"networking":          "networking.k8s.io",
+				"networking-istio-io": "networking-istio.io",
+			},
+			Error: true,
+		},
+		{
+			Name:  "no group",
+			Group: "",
+			Error: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			c := &Config{GroupFullNames: test.FullNames}
+			actual, err := c.registerCustomResourceGroup(test.Group)
+			if test.Error {
+				if err == nil {
+					t.Fatalf("expected an error, got group %q", actual)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if actual != test.Expected {
+				t.Errorf("got group %q, expected %q", actual, test.Expected)
+			}
+			if c.GroupFullNames[actual] != test.Group {
+				t.Errorf("got full name %q, expected %q", c.GroupFullNames[actual], test.Group)
+			}
+			if c.OperationGroupMap[actual] != test.ExpectedOpGrp {
+				t.Errorf("got operation group %q, expected %q", c.OperationGroupMap[actual], test.ExpectedOpGrp)
+			}
+		})
+	}
+}
+
+const testCustomResourceDefinition = `
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: crontabs.stable.example.com
+spec:
+  group: stable.example.com
+  scope: Namespaced
+  names:
+    kind: CronTab
+    plural: crontabs
+  versions:
+  - name: v1
+    served: true
+    schema:
+      openAPIV3Schema:
+        type: object
+        properties:
+          spec:
+            type: object
+            properties:
+              cronSpec:
+                type: string
+              port:
+                x-kubernetes-int-or-string: true
+              value:
+                anyOf:
+                - type: integer
+                - type: string
+              source:
+                anyOf:
+                - required: [url]
+                - required: [path]
+                properties:
+                  url:
+                    type: string
+                  path:
+                    type: string
+`
+
+func TestCustomResourceDefinitionToSpec(t *testing.T) {
+	crd := &customResourceDefinition{}
+	if err := yamlv3.Unmarshal([]byte(testCustomResourceDefinition), crd); err != nil {
+		t.Fatalf("could not parse CustomResourceDefinition: %v", err)
+	}
+	doc, err := crd.toSpec("stable", "StableExampleCom")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	definitions := doc.Spec().Definitions
+	names := []string{}
+	for name := range definitions {
+		names = append(names, strings.TrimPrefix(name, testPrefix))
+	}
+	sort.Strings(names)
+	expectedNames := []string{"CronTab", "CronTabList", "CronTabSpec", "CronTabSpecSource"}
+	if !reflect.DeepEqual(names, expectedNames) {
+		t.Errorf("got definitions %v, expected %v", names, expectedNames)
+	}
+
+	expectedTypes := map[string]string{
+		"cronSpec": "string",
+		"value":    "integer or string",
+		"source":   "CronTabSpecSource",
+	}
+	spec := definitions[testPrefix+"CronTabSpec"]
+	if port := spec.Properties["port"]; port.Ref.String() != intOrStringRef {
+		t.Errorf("property port: got reference %q, expected %q", port.Ref.String(), intOrStringRef)
+	}
+	for name, expected := range expectedTypes {
+		actual, err := GetTypeName(spec.Properties[name])
+		if err != nil {
+			t.Errorf("property %s: unexpected error: %v", name, err)
+		} else if actual != expected {
+			t.Errorf("property %s: got type %q, expected %q", name, actual, expected)
+		}
+	}
+
+	paths := []string{}
+	for path := range doc.Spec().Paths.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	expectedPaths := []string{
+		"/apis/stable.example.com/v1/crontabs",
+		"/apis/stable.example.com/v1/namespaces/{namespace}/crontabs",
+		"/apis/stable.example.com/v1/namespaces/{namespace}/crontabs/{name}",
+		"/apis/stable.example.com/v1/watch/crontabs",
+		"/apis/stable.example.com/v1/watch/namespaces/{namespace}/crontabs",
+		"/apis/stable.example.com/v1/watch/namespaces/{namespace}/crontabs/{name}",
+	}
+	if !reflect.DeepEqual(paths, expectedPaths) {
+		t.Errorf("got paths %v, expected %v", paths, expectedPaths)
+	}
+}
+
+func TestGetGroupVersionKindSub(t *testing.T) {
+	c := &Config{GroupFullNames: map[string]string{"networking": "networking.k8s.io"}}
+	group, err := c.registerCustomResourceGroup("networking.istio.io")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// the operations of a custom resource must use the group of its definitions
+	definitionGroup, _, _ := GetDefinitionVersionKindFromString(crdDefinitionName("networking.istio.io", group, "v1", "Gateway"))
+
+	tests := []struct {
+		Name     string
+		Path     string
+		Expected []string
+	}{
+		{
+			Name:     "namespaced",
+			Path:     "/apis/apps/v1/namespaces/{namespace}/deployments/{name}/scale",
+			Expected: []string{"apps", "v1", "deployments", "scale"},
+		},
+		{
+			Name:     "namespaced with a full group name",
+			Path:     "/apis/networking.k8s.io/v1/namespaces/{namespace}/ingresses/{name}/status",
+			Expected: []string{"networking", "v1", "ingresses", "status"},
+		},
+		{
+			Name:     "unnamespaced",
+			Path:     "/apis/certificates.k8s.io/v1/certificatesigningrequests/{name}/approval",
+			Expected: []string{"certificates.k8s.io", "v1", "certificatesigningrequests", "approval"},
+		},
+		{
+			Name:     "namespaced custom resource",
+			Path:     "/apis/networking.istio.io/v1/namespaces/{namespace}/gateways/{name}/status",
+			Expected: []string{definitionGroup, "v1", "gateways", "status"},
+		},
+		{
+			Name:     "unnamespaced custom resource",
+			Path:     "/apis/networking.istio.io/v1/gateways/{name}/status",
+			Expected: []string{definitionGroup, "v1", "gateways", "status"},
+		},
+		{
+			Name:     "not a subresource",
+			Path:     "/apis/apps/v1/namespaces/{namespace}/deployments/{name}",
+			Expected: []string{"", "", "", ""},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			o := &Operation{Path: test.Path}
+			g, v, k, s := o.GetGroupVersionKindSub(c)
+			if actual := []string{g, v, k, s}; !reflect.DeepEqual(actual, test.Expected) {
+				t.Errorf("got %v, expected %v", actual, test.Expected)
+			}
+		})
+	}
+}
@@ -57,14 +57,18 @@ func NewDefinitions(config *Config, specs []*loads.Document) (*Definitions, erro
 		return nil, fmt.Errorf("failed to load definitions from OpenAPI specs: %w", err)
 	}
 
-	s.initialize()
+	if err := s.initialize(); err != nil {
+		return nil, err
+	}
 	return s, nil
 }
 
-func (s *Definitions) initialize() {
+func (s *Definitions) initialize() error {
 	// initialize fields for all definitions
 	for _, d := range s.All {
-		s.InitializeFields(d)
+		if err := s.InitializeFields(d); err != nil {
+			return err
+		}
 	}
 
 	for _, d := range s.All {
@@ -131,6 +135,7 @@ func (s *Definitions) initialize() {
 			}
 		}
 	}
+	return nil
 }
 
 func (s *Definitions) getInlineDefinitionNames(parent string) []string {
@@ -270,18 +275,22 @@ func (s *Definitions) findReferencedDefinitions(schema *spec.Schema, visited map
 	return out
 }
 
-func (s *Definitions) parameterToField(param spec.Parameter) *Field {
+func (s *Definitions) parameterToField(param spec.Parameter) (*Field, error) {
 	f := &Field{
 		Name:        param.Name,
 		Description: strings.ReplaceAll(param.Description, "\n", " "),
 	}
 	if param.Schema != nil {
-		f.Type = GetTypeName(*param.Schema)
+		typeName, err := GetTypeName(*param.Schema)
+		if err != nil {
+			return nil, fmt.Errorf("parameter %q: %w", param.Name, err)
+		}
+		f.Type = typeName
 		if fieldType, ok := s.GetForSchema(*param.Schema); ok {
 			f.Definition = fieldType
 		}
 	}
-	return f
+	return f, nil
 }
 
 // GetByVersionKind looks up a definition using its primary key (version,kind)
@@ -300,12 +309,16 @@ func (s *Definitions) GetForSchema(schema spec.Schema) (*Definition, bool) {
 }
 
 // Initializes the fields for a definition
-func (s *Definitions) InitializeFields(d *Definition) {
+func (s *Definitions) InitializeFields(d *Definition) error {
 	for fieldName, property := range d.schema.Properties {
+		typeName, err := GetTypeName(property)
+		if err != nil {
+			return fmt.Errorf("field %q of %s: %w", fieldName, d.Key(), err)
+		}
 		des := strings.ReplaceAll(property.Description, "\n", " ")
 		f := &Field{
 			Name:        fieldName,
-			Type:        GetTypeName(property),
+			Type:        typeName,
 			Description: EscapeAsterisks(des),
 		}
 		if len(property.Extensions) > 0 {
@@ -322,6 +335,7 @@ func (s *Definitions) InitializeFields(d *Definition) {
 		}
 		d.Fields = append(d.Fields, f)
 	}
+	return nil
 }
 
 func (d *Definition) GroupDisplayName() string {
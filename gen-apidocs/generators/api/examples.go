@@ -18,12 +18,15 @@ package api
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
+	"unicode"
 )
 
 var ExampleProviders = []ExampleProvider{
 	KubectlExample{},
 	CurlExample{},
+	GoClientExample{},
 }
 
 var EmptyExampleProviders = []ExampleProvider{
@@ -33,6 +36,7 @@ var EmptyExampleProviders = []ExampleProvider{
 var _ ExampleProvider = &EmptyExample{}
 var _ ExampleProvider = &CurlExample{}
 var _ ExampleProvider = &KubectlExample{}
+var _ ExampleProvider = &GoClientExample{}
 
 func GetExampleProviders() []ExampleProvider {
 	if *BuildOps {
@@ -249,3 +253,170 @@ func (ke KubectlExample) GetResponse(o *Operation) string {
 	}
 	return ""
 }
+
+func (ge GoClientExample) GetSample(d *Definition) string {
+	return d.Sample.Sample
+}
+
+func (ge GoClientExample) GetRequestMessage() string {
+	return "Go code with `client-go`"
+}
+
+func (ge GoClientExample) GetResponseMessage() string {
+	return "Response Body"
+}
+
+func (ge GoClientExample) GetTab() string {
+	return "bdocs-tab:go"
+}
+
+func (ge GoClientExample) GetRequestType() string {
+	return "bdocs-tab:go_go"
+}
+
+func (ge GoClientExample) GetResponseType() string {
+	return "bdocs-tab:go_json"
+}
+
+func (ge GoClientExample) GetSampleType() string {
+	return "bdocs-tab:go_yaml"
+}
+
+// GetRequest returns the code calling the typed clientset, e.g.
+// clientset.AppsV1().Deployments("default").Create(...). The body of the
+// request is unmarshalled from the example YAML into the typed object.
+func (ge GoClientExample) GetRequest(o *Operation) string {
+	c := o.ExampleConfig
+	y := c.Request
+	if len(y) == 0 && len(c.Name) == 0 {
+		return ""
+	}
+
+	d := o.Definition
+	if !inClientset(d) {
+		return ""
+	}
+	v := goVarName(d.Name)
+	typ := fmt.Sprintf("%s.%s", goPackageAlias(d.Group.String(), d.Version), d.Name)
+	client := fmt.Sprintf("clientset.%s%s().%s(%s)",
+		titleCase(d.Group.String()), titleCase(d.Version.String()), goPlural(d.Name), goNamespace(o))
+	name := strconv.Quote(c.Name)
+
+	switch o.Type.Name {
+	case "Create":
+		return fmt.Sprintf("%s\nresult, err := %s.Create(context.TODO(), %s, metav1.CreateOptions{})",
+			goUnmarshal(v, "&"+typ+"{}", y), client, v)
+	case "Delete":
+		if len(strings.TrimSpace(y)) == 0 {
+			return fmt.Sprintf("err := %s.Delete(context.TODO(), %s, metav1.DeleteOptions{})", client, name)
+		}
+		return fmt.Sprintf("%s\nerr := %s.Delete(context.TODO(), %s, *options)",
+			goUnmarshal("options", "&metav1.DeleteOptions{}", y), client, name)
+	case "List":
+		return fmt.Sprintf("result, err := %s.List(context.TODO(), metav1.ListOptions{})", client)
+	case "Patch":
+		return fmt.Sprintf("patch := []byte(%s)\nresult, err := %s.Patch(context.TODO(), %s, types.StrategicMergePatchType, patch, metav1.PatchOptions{})",
+			goRawString(strings.TrimSpace(y)), client, name)
+	case "Read":
+		return fmt.Sprintf("result, err := %s.Get(context.TODO(), %s, metav1.GetOptions{})", client, name)
+	case "Replace":
+		return fmt.Sprintf("%s\nresult, err := %s.Update(context.TODO(), %s, metav1.UpdateOptions{})",
+			goUnmarshal(v, "&"+typ+"{}", y), client, v)
+	case "Watch":
+		options := "metav1.ListOptions{}"
+		if len(c.Name) > 0 {
+			options = fmt.Sprintf("metav1.ListOptions{FieldSelector: %s}", strconv.Quote("metadata.name="+c.Name))
+		}
+		return fmt.Sprintf("watcher, err := %s.Watch(context.TODO(), %s)\nif err != nil {\n\tpanic(err)\n}\nfor event := range watcher.ResultChan() {\n\tfmt.Println(event.Type, event.Object)\n}",
+			client, options)
+	}
+	return ""
+}
+
+func (ge GoClientExample) GetResponse(o *Operation) string {
+	// the response is only shown with a request, and Delete only returns an error
+	if len(ge.GetRequest(o)) == 0 || o.Type.Name == "Delete" {
+		return ""
+	}
+	return o.ExampleConfig.Response
+}
+
+// inClientset tests if the typed clients of the group of a definition are in
+// the client-go clientset. The custom resources, and the groups served by the
+// extension and aggregation API servers, have clientsets of their own.
+func inClientset(d *Definition) bool {
+	switch d.GroupFullName {
+	case "apiextensions.k8s.io", "apiregistration.k8s.io":
+		return false
+	}
+	return !strings.Contains(d.GroupFullName, ".") || strings.HasSuffix(d.GroupFullName, ".k8s.io")
+}
+
+// goUnmarshal returns the code unmarshalling a YAML document into a new
+// object assigned to a variable.
+func goUnmarshal(v, value, y string) string {
+	return fmt.Sprintf("%s := %s\nif err := yaml.Unmarshal([]byte(%s), %s); err != nil {\n\tpanic(err)\n}",
+		v, value, goRawString("\n"+strings.TrimLeft(y, "\n")), v)
+}
+
+// goRawString returns a raw string literal, or an interpreted one if the
+// string contains a backquote.
+func goRawString(s string) string {
+	if strings.Contains(s, "`") {
+		return strconv.Quote(s)
+	}
+	return "`" + s + "`"
+}
+
+// goVarName returns the variable name for an object of a kind, e.g.
+// 'deployment' for 'Deployment'.
+func goVarName(kind string) string {
+	runes := []rune(kind)
+	for i := 0; i < len(runes) && unicode.IsUpper(runes[i]); i++ {
+		// keep the last upper case letter of an initialism followed by a word,
+		// e.g. 'apiService' for 'APIService'
+		if i > 0 && i+1 < len(runes) && unicode.IsLower(runes[i+1]) {
+			break
+		}
+		runes[i] = unicode.ToLower(runes[i])
+	}
+	return string(runes)
+}
+
+// goPackageAlias returns the conventional alias of the package of the API
+// types, e.g. 'appsv1' for 'k8s.io/api/apps/v1'.
+func goPackageAlias(group string, version ApiVersion) string {
+	return strings.ToLower(group + version.String())
+}
+
+// goPlural returns the name of the typed client method for a kind, following
+// the pluralization rules of client-gen, e.g. 'NetworkPolicies'.
+func goPlural(kind string) string {
+	switch {
+	case kind == "Endpoints":
+		return kind
+	case strings.HasSuffix(kind, "s"), strings.HasSuffix(kind, "x"), strings.HasSuffix(kind, "z"),
+		strings.HasSuffix(kind, "ch"), strings.HasSuffix(kind, "sh"):
+		return kind + "es"
+	case strings.HasSuffix(kind, "y") && len(kind) > 1 && !strings.ContainsRune("aeiou", rune(kind[len(kind)-2])):
+		return strings.TrimSuffix(kind, "y") + "ies"
+	}
+	return kind + "s"
+}
+
+// goNamespace returns the namespace argument of the typed client, which is
+// empty for the cluster-scoped resources, and metav1.NamespaceAll for the
+// operations across all namespaces.
+func goNamespace(o *Operation) string {
+	if strings.Contains(o.Path, "{namespace}") {
+		ns := o.ExampleConfig.Namespace
+		if ns == "" {
+			ns = "default"
+		}
+		return strconv.Quote(ns)
+	}
+	if strings.HasSuffix(o.ID, "ForAllNamespaces") {
+		return "metav1.NamespaceAll"
+	}
+	return ""
+}
@@ -0,0 +1,147 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGoPlural(t *testing.T) {
+	tests := map[string]string{
+		"Pod":                   "Pods",
+		"Endpoints":             "Endpoints",
+		"Ingress":               "Ingresses",
+		"NetworkPolicy":         "NetworkPolicies",
+		"Gateway":               "Gateways",
+		"ResourceClaimTemplate": "ResourceClaimTemplates",
+		"Box":                   "Boxes",
+		"Batch":                 "Batches",
+		"Mesh":                  "Meshes",
+		"Y":                     "Ys",
+	}
+	for kind, expected := range tests {
+		if actual := goPlural(kind); actual != expected {
+			t.Errorf("%s: got %q, expected %q", kind, actual, expected)
+		}
+	}
+}
+
+func TestGoVarName(t *testing.T) {
+	tests := map[string]string{
+		"Deployment":         "deployment",
+		"APIService":         "apiService",
+		"CSIDriver":          "csiDriver",
+		"PodIP":              "podIP",
+		"IPAddress":          "ipAddress",
+		"HorizontalPodScale": "horizontalPodScale",
+	}
+	for kind, expected := range tests {
+		if actual := goVarName(kind); actual != expected {
+			t.Errorf("%s: got %q, expected %q", kind, actual, expected)
+		}
+	}
+}
+
+func TestGoNamespace(t *testing.T) {
+	tests := []struct {
+		Name      string
+		ID        string
+		Path      string
+		Namespace string
+		Expected  string
+	}{
+		{
+			Name:     "namespaced",
+			ID:       "readAppsV1NamespacedDeployment",
+			Path:     "/apis/apps/v1/namespaces/{namespace}/deployments/{name}",
+			Expected: `"default"`,
+		},
+		{
+			Name:      "namespace of the example",
+			ID:        "readAppsV1NamespacedDeployment",
+			Path:      "/apis/apps/v1/namespaces/{namespace}/deployments/{name}",
+			Namespace: "kube-system",
+			Expected:  `"kube-system"`,
+		},
+		{
+			Name:     "all namespaces",
+			ID:       "listAppsV1DeploymentForAllNamespaces",
+			Path:     "/apis/apps/v1/deployments",
+			Expected: "metav1.NamespaceAll",
+		},
+		{
+			Name:     "cluster scoped",
+			ID:       "readCoreV1Node",
+			Path:     "/api/v1/nodes/{name}",
+			Expected: "",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			o := &Operation{ID: test.ID, Path: test.Path, ExampleConfig: ExampleConfig{Namespace: test.Namespace}}
+			if actual := goNamespace(o); actual != test.Expected {
+				t.Errorf("got %q, expected %q", actual, test.Expected)
+			}
+		})
+	}
+}
+
+func TestGoClientExampleGroups(t *testing.T) {
+	tests := []struct {
+		Name      string
+		Group     string
+		FullGroup string
+		Expected  string
+	}{
+		{"core", "core", "core", "clientset.CoreV1().Widgets"},
+		{"short name", "apps", "apps", "clientset.AppsV1().Widgets"},
+		{"kubernetes group", "networking", "networking.k8s.io", "clientset.NetworkingV1().Widgets"},
+		{"extension API server", "apiextensions", "apiextensions.k8s.io", ""},
+		{"custom resource", "stable", "stable.example.com", ""},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			o := &Operation{
+				ID:   "readWidget",
+				Path: "/apis/" + test.FullGroup + "/v1/widgets/{name}",
+				Type: OperationType{Name: "Read"},
+				Definition: &Definition{
+					Name:          "Widget",
+					Group:         ApiGroup(test.Group),
+					GroupFullName: test.FullGroup,
+					Version:       "v1",
+				},
+				ExampleConfig: ExampleConfig{Name: "example", Response: "{}"},
+			}
+			request := GoClientExample{}.GetRequest(o)
+			response := GoClientExample{}.GetResponse(o)
+			if test.Expected == "" {
+				if request != "" || response != "" {
+					t.Errorf("expected no example, got request %q and response %q", request, response)
+				}
+				return
+			}
+			if !strings.Contains(request, test.Expected) {
+				t.Errorf("expected %q in request %q", test.Expected, request)
+			}
+			if response != "{}" {
+				t.Errorf("got response %q, expected %q", response, "{}")
+			}
+		})
+	}
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package api
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -32,7 +33,9 @@ const (
 	typeKey          = "x-kubernetes-group-version-kind"
 )
 
-// Loads all of the open-api documents
+// Loads all of the open-api documents. Both Swagger 2.0 documents, e.g.
+// swagger.json, and OpenAPI v3 documents, e.g. the files in
+// api/openapi-spec/v3, are loaded.
 func LoadOpenApiSpec() ([]*loads.Document, error) {
 	docs := []*loads.Document{}
 	err := filepath.Walk(VersionedConfigDir, func(path string, info os.FileInfo, err error) error {
@@ -43,10 +46,26 @@ func LoadOpenApiSpec() ([]*loads.Document, error) {
 		if ext != ".json" {
 			return nil
 		}
-		var d *loads.Document
-		d, err = loads.JSONSpec(path)
+		raw, err := os.ReadFile(path)
 		if err != nil {
-			return fmt.Errorf("could not load json file %s as api-spec %v", path, err)
+			return fmt.Errorf("could not read json file %s: %w", path, err)
+		}
+		var doc map[string]interface{}
+		if err := json.Unmarshal(raw, &doc); err != nil {
+			return fmt.Errorf("could not parse json file %s: %w", path, err)
+		}
+
+		var d *loads.Document
+		if isOpenApiV3(doc) {
+			d, err = loadOpenApiV3(doc)
+			if err != nil {
+				return fmt.Errorf("could not load json file %s as openapi v3 spec: %w", path, err)
+			}
+		} else {
+			d, err = loads.JSONSpec(path)
+			if err != nil {
+				return fmt.Errorf("could not load json file %s as api-spec %v", path, err)
+			}
 		}
 		docs = append(docs, d)
 		return nil
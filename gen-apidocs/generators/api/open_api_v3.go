@@ -0,0 +1,268 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/go-openapi/loads"
+)
+
+// The OpenAPI v3 documents published by Kubernetes, one per group version
+// under api/openapi-spec/v3, are converted to Swagger 2.0 documents, so that
+// they are merged into the same Definitions and Operations as swagger.json.
+// The schemas are kept as is, with their oneOf, nullable and default
+// properties, except that the references are rewritten.
+
+const (
+	v3SchemaPrefix    = "#/components/schemas/"
+	v3ParameterPrefix = "#/components/parameters/"
+	v2SchemaPrefix    = "#/definitions/"
+)
+
+// isOpenApiV3 tests if a parsed JSON document is an OpenAPI v3 document.
+func isOpenApiV3(doc map[string]interface{}) bool {
+	v, ok := doc["openapi"].(string)
+	return ok && strings.HasPrefix(v, "3.")
+}
+
+// loadOpenApiV3 converts an OpenAPI v3 document to a Swagger 2.0 document.
+func loadOpenApiV3(doc map[string]interface{}) (*loads.Document, error) {
+	components, _ := doc["components"].(map[string]interface{})
+	schemas, _ := components["schemas"].(map[string]interface{})
+	parameters, _ := components["parameters"].(map[string]interface{})
+
+	definitions := map[string]interface{}{}
+	for name, s := range schemas {
+		definitions[name] = convertV3Schema(s)
+	}
+
+	paths := map[string]interface{}{}
+	docPaths, _ := doc["paths"].(map[string]interface{})
+	for path, v := range docPaths {
+		item, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		converted := map[string]interface{}{}
+		for key, value := range item {
+			switch key {
+			case "parameters":
+				params, err := convertV3Parameters(value, parameters)
+				if err != nil {
+					return nil, fmt.Errorf("path %s: %w", path, err)
+				}
+				converted[key] = params
+			case "get", "put", "post", "delete", "options", "head", "patch":
+				op, err := convertV3Operation(value, parameters)
+				if err != nil {
+					return nil, fmt.Errorf("operation %s %s: %w", strings.ToUpper(key), path, err)
+				}
+				converted[key] = op
+			default:
+				if strings.HasPrefix(key, "x-") {
+					converted[key] = value
+				}
+			}
+		}
+		paths[path] = converted
+	}
+
+	swagger := map[string]interface{}{
+		"swagger":     "2.0",
+		"info":        doc["info"],
+		"paths":       paths,
+		"definitions": definitions,
+	}
+	raw, err := json.Marshal(swagger)
+	if err != nil {
+		return nil, err
+	}
+	return loads.Analyzed(json.RawMessage(raw), "2.0")
+}
+
+// convertV3Schema rewrites the references to the component schemas in a
+// schema. A reference wrapped in a single-element allOf, which is how the
+// v3 documents add a description or a default to a reference, is unwrapped.
+func convertV3Schema(v interface{}) interface{} {
+	switch s := v.(type) {
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(s))
+		for key, value := range s {
+			if key == "$ref" {
+				if ref, ok := value.(string); ok {
+					value = strings.Replace(ref, v3SchemaPrefix, v2SchemaPrefix, 1)
+				}
+			}
+			result[key] = convertV3Schema(value)
+		}
+		if allOf, ok := result["allOf"].([]interface{}); ok && len(allOf) == 1 {
+			if ref, ok := allOf[0].(map[string]interface{})["$ref"]; ok && result["$ref"] == nil {
+				result["$ref"] = ref
+				delete(result, "allOf")
+			}
+		}
+		return result
+	case []interface{}:
+		result := make([]interface{}, len(s))
+		for i, value := range s {
+			result[i] = convertV3Schema(value)
+		}
+		return result
+	}
+	return v
+}
+
+// convertV3Parameters converts the path, query and header parameters. Their
+// type is moved from the schema to the parameter, as in Swagger 2.0. The
+// references to the component parameters are resolved.
+func convertV3Parameters(v interface{}, components map[string]interface{}) ([]interface{}, error) {
+	list, _ := v.([]interface{})
+	result := []interface{}{}
+	for _, item := range list {
+		p, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if ref, ok := p["$ref"].(string); ok {
+			name := strings.TrimPrefix(ref, v3ParameterPrefix)
+			if p, ok = components[name].(map[string]interface{}); !ok {
+				return nil, fmt.Errorf("unresolved parameter reference %q", ref)
+			}
+		}
+
+		param := map[string]interface{}{}
+		for key, value := range p {
+			if key != "schema" && key != "style" && key != "explode" && key != "example" {
+				param[key] = value
+			}
+		}
+		if schema, ok := convertV3Schema(p["schema"]).(map[string]interface{}); ok {
+			for _, key := range []string{"type", "format", "items", "default", "enum", "uniqueItems"} {
+				if value, ok := schema[key]; ok {
+					param[key] = value
+				}
+			}
+		}
+		result = append(result, param)
+	}
+	return result, nil
+}
+
+// contentSchema returns the schema of the JSON media type of a request body
+// or a response, or of the first media type if JSON is not found.
+func contentSchema(v interface{}) (interface{}, []string) {
+	obj, _ := v.(map[string]interface{})
+	content, _ := obj["content"].(map[string]interface{})
+	if len(content) == 0 {
+		return nil, nil
+	}
+
+	var types []string
+	for t := range content {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+
+	mediaType := types[0]
+	if _, ok := content["application/json"]; ok {
+		mediaType = "application/json"
+	}
+	media, _ := content[mediaType].(map[string]interface{})
+	return convertV3Schema(media["schema"]), types
+}
+
+// convertV3Operation converts an operation. The request body becomes the
+// 'body' parameter and the response content becomes the response schema.
+func convertV3Operation(v interface{}, components map[string]interface{}) (map[string]interface{}, error) {
+	op, ok := v.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("invalid operation")
+	}
+
+	result := map[string]interface{}{}
+	for _, key := range []string{"operationId", "description", "summary", "tags", "deprecated"} {
+		if value, ok := op[key]; ok {
+			result[key] = value
+		}
+	}
+	for key, value := range op {
+		if strings.HasPrefix(key, "x-") {
+			result[key] = value
+		}
+	}
+
+	params, err := convertV3Parameters(op["parameters"], components)
+	if err != nil {
+		return nil, err
+	}
+	if body, ok := op["requestBody"].(map[string]interface{}); ok {
+		schema, types := contentSchema(body)
+		if schema != nil {
+			param := map[string]interface{}{
+				"name":   "body",
+				"in":     "body",
+				"schema": schema,
+			}
+			if desc, ok := body["description"]; ok {
+				param["description"] = desc
+			}
+			if required, ok := body["required"]; ok {
+				param["required"] = required
+			}
+			params = append(params, param)
+			result["consumes"] = types
+		}
+	}
+	result["parameters"] = params
+
+	responses := map[string]interface{}{}
+	docResponses, _ := op["responses"].(map[string]interface{})
+	var produces []string
+	for code, value := range docResponses {
+		resp, _ := value.(map[string]interface{})
+		converted := map[string]interface{}{"description": resp["description"]}
+		if schema, types := contentSchema(resp); schema != nil {
+			converted["schema"] = schema
+			for _, t := range types {
+				if !containsString(produces, t) {
+					produces = append(produces, t)
+				}
+			}
+		}
+		responses[code] = converted
+	}
+	result["responses"] = responses
+	if len(produces) > 0 {
+		sort.Strings(produces)
+		result["produces"] = produces
+	}
+
+	return result, nil
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,225 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"encoding/json"
+	"os"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/go-openapi/loads"
+	"github.com/go-openapi/spec"
+)
+
+const (
+	widgetDef    = "io.k8s.api.example.v1.Widget"
+	widgetPath   = "/apis/example.k8s.io/v1/namespaces/{namespace}/widgets/{name}"
+	widgetRefDef = "#/definitions/io.k8s.api.example.v1.WidgetReference"
+)
+
+// loadTestOpenApiV3 converts the OpenAPI v3 document in the testdata directory.
+func loadTestOpenApiV3(t *testing.T) *loads.Document {
+	t.Helper()
+	data, err := os.ReadFile("testdata/openapi_v3.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc := map[string]interface{}{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatal(err)
+	}
+	if !isOpenApiV3(doc) {
+		t.Fatalf("expected an OpenAPI v3 document")
+	}
+	d, err := loadOpenApiV3(doc)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return d
+}
+
+func TestLoadOpenApiV3Definitions(t *testing.T) {
+	definitions := loadTestOpenApiV3(t).Spec().Definitions
+
+	names := []string{}
+	for name := range definitions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	expected := []string{
+		widgetDef,
+		widgetDef + "Reference",
+		widgetDef + "Spec",
+		"io.k8s.apimachinery.pkg.apis.meta.v1.ObjectMeta",
+	}
+	if !reflect.DeepEqual(names, expected) {
+		t.Errorf("got definitions %v, expected %v", names, expected)
+	}
+
+	widget := definitions[widgetDef]
+	if gvk, ok := widget.Extensions["x-kubernetes-group-version-kind"]; !ok || gvk == nil {
+		t.Errorf("expected the group version kind extension to be kept")
+	}
+	widgetSpec := definitions[widgetDef+"Spec"]
+
+	tests := []struct {
+		Name     string
+		Schema   spec.Schema
+		Ref      string
+		TypeName string
+	}{
+		{
+			Name:     "reference",
+			Schema:   widget.Properties["spec"],
+			Ref:      "#/definitions/" + widgetDef + "Spec",
+			TypeName: "WidgetSpec",
+		},
+		{
+			Name:     "reference wrapped in allOf",
+			Schema:   widget.Properties["metadata"],
+			Ref:      "#/definitions/io.k8s.apimachinery.pkg.apis.meta.v1.ObjectMeta",
+			TypeName: "ObjectMeta",
+		},
+		{
+			Name:     "nullable reference",
+			Schema:   widgetSpec.Properties["parent"],
+			Ref:      widgetRefDef,
+			TypeName: "WidgetReference",
+		},
+		{
+			Name:     "array of references",
+			Schema:   widgetSpec.Properties["children"],
+			TypeName: "WidgetReference array",
+		},
+		{
+			Name:     "oneOf",
+			Schema:   widgetSpec.Properties["size"],
+			TypeName: "integer or string",
+		},
+		{
+			Name:     "anyOf",
+			Schema:   widgetSpec.Properties["limit"],
+			TypeName: "number or boolean",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			if ref := test.Schema.Ref.String(); ref != test.Ref {
+				t.Errorf("got reference %q, expected %q", ref, test.Ref)
+			}
+			if len(test.Schema.AllOf) > 0 {
+				t.Errorf("expected the allOf wrapper to be removed")
+			}
+			typeName, err := GetTypeName(test.Schema)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if typeName != test.TypeName {
+				t.Errorf("got type %q, expected %q", typeName, test.TypeName)
+			}
+		})
+	}
+
+	if !widgetSpec.Properties["parent"].Nullable {
+		t.Errorf("expected nullable to be kept")
+	}
+	if items := widgetSpec.Properties["children"].Items.Schema; items.Ref.String() != widgetRefDef {
+		t.Errorf("got item reference %q, expected %q", items.Ref.String(), widgetRefDef)
+	}
+}
+
+func TestLoadOpenApiV3Operations(t *testing.T) {
+	item := loadTestOpenApiV3(t).Spec().Paths.Paths[widgetPath]
+
+	params := map[string]spec.Parameter{}
+	for _, p := range item.Parameters {
+		params[p.Name] = p
+	}
+	for _, name := range []string{"name", "namespace", "pretty"} {
+		p, ok := params[name]
+		if !ok {
+			t.Errorf("parameter %s not found", name)
+			continue
+		}
+		if p.Type != "string" {
+			t.Errorf("parameter %s: got type %q, expected the type of the schema", name, p.Type)
+		}
+		if p.Schema != nil {
+			t.Errorf("parameter %s: expected the schema to be removed", name)
+		}
+	}
+	if p := params["name"]; p.In != "path" || !p.Required || p.Description != "name of the Widget" {
+		t.Errorf("expected the parameter reference to be resolved, got %+v", p.ParamProps)
+	}
+
+	get := item.Get
+	if get == nil {
+		t.Fatalf("get operation not found")
+	}
+	if get.ID != "readExampleV1NamespacedWidget" {
+		t.Errorf("got operation ID %q", get.ID)
+	}
+	if action, _ := get.Extensions.GetString("x-kubernetes-action"); action != "get" {
+		t.Errorf("expected the extensions to be kept, got action %q", action)
+	}
+	if expected := []string{"application/json", "application/yaml"}; !reflect.DeepEqual(get.Produces, expected) {
+		t.Errorf("got produces %v, expected %v", get.Produces, expected)
+	}
+	ok := get.Responses.StatusCodeResponses[200]
+	if ok.Schema == nil || ok.Schema.Ref.String() != "#/definitions/"+widgetDef {
+		t.Errorf("expected the response schema to reference the definition, got %v", ok.Schema)
+	}
+	if unauthorized := get.Responses.StatusCodeResponses[401]; unauthorized.Schema != nil {
+		t.Errorf("expected no schema for a response without content")
+	}
+
+	put := item.Put
+	if put == nil {
+		t.Fatalf("put operation not found")
+	}
+	if len(put.Parameters) != 1 {
+		t.Fatalf("expected the request body parameter, got %v", put.Parameters)
+	}
+	body := put.Parameters[0]
+	if body.In != "body" || body.Name != "body" || !body.Required {
+		t.Errorf("got body parameter %+v", body.ParamProps)
+	}
+	if body.Schema == nil || body.Schema.Ref.String() != "#/definitions/"+widgetDef {
+		t.Errorf("expected the body schema to reference the definition, got %v", body.Schema)
+	}
+	if expected := []string{"*/*"}; !reflect.DeepEqual(put.Consumes, expected) {
+		t.Errorf("got consumes %v, expected %v", put.Consumes, expected)
+	}
+}
+
+func TestLoadOpenApiV3UnresolvedParameter(t *testing.T) {
+	doc := map[string]interface{}{
+		"openapi": "3.0.0",
+		"paths": map[string]interface{}{
+			"/api/v1/pods": map[string]interface{}{
+				"parameters": []interface{}{
+					map[string]interface{}{"$ref": "#/components/parameters/missing"},
+				},
+			},
+		},
+	}
+	if _, err := loadOpenApiV3(doc); err == nil {
+		t.Errorf("expected an error for an unresolved parameter reference")
+	}
+}
@@ -161,12 +161,21 @@ var (
 		`^/apis/([A-Za-z0-9\.]+)/([A-Za-z0-9]+)/([A-Za-z0-9\.]+)/\{name\}/([A-Za-z0-9\.]+)$`)
 )
 
-func (o *Operation) GetGroupVersionKindSub() (string, string, string, string) {
+// GetGroupVersionKindSub returns the group, version, kind and subresource of
+// a subresource operation. The group of a custom resource is the short name
+// it was registered with, the same as in the names of its definitions.
+func (o *Operation) GetGroupVersionKindSub(c *Config) (string, string, string, string) {
 	if matchNamespaced.MatchString(o.Path) {
 		m := matchNamespaced.FindStringSubmatch(o.Path)
+		if group, ok := c.customResourceGroups[m[1]]; ok {
+			return group, m[2], m[3], m[4]
+		}
 		return strings.Split(m[1], ".")[0], m[2], m[3], m[4]
 	} else if matchUnnamespaced.MatchString(o.Path) {
 		m := matchUnnamespaced.FindStringSubmatch(o.Path)
+		if group, ok := c.customResourceGroups[m[1]]; ok {
+			return group, m[2], m[3], m[4]
+		}
 		return m[1], m[2], m[3], m[4]
 	}
 	return "", "", "", ""
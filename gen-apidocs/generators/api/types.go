@@ -258,6 +258,10 @@ type Config struct {
 	Operations  Operations
 	SpecTitle   string
 	SpecVersion string
+
+	// customResourceGroups maps the full names of the custom resource groups
+	// to their short names.
+	customResourceGroups map[string]string
 }
 
 type Field struct {
@@ -361,6 +365,7 @@ type ExampleProvider interface {
 type EmptyExample struct{}
 type CurlExample struct{}
 type KubectlExample struct{}
+type GoClientExample struct{}
 
 type Resource struct {
 	// Name is the display name of this Resource
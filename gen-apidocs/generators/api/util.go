@@ -71,21 +71,41 @@ func GetDefinitionVersionKindFromString(s string) (string, string, string) {
 
 // GetTypeName returns the display name of a Schema.  This is the api kind for definitions and the type for
 // primitive types.  Arrays of objects have "array" appended.
-func GetTypeName(s spec.Schema) string {
+func GetTypeName(s spec.Schema) (string, error) {
 	// Get the reference for complex types
 	if IsDefinition(s) {
 		_, _, name := GetDefinitionVersionKind(s)
-		return name
+		return name, nil
 	}
 	// Recurse if type is array
 	if IsArray(s) {
-		return fmt.Sprintf("%s array", GetTypeName(*s.Items.Schema))
+		name, err := GetTypeName(*s.Items.Schema)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%s array", name), nil
 	}
 	// Get the value for primitive types
 	if len(s.Type) > 0 {
-		return s.Type[0]
+		return s.Type[0], nil
 	}
-	panic(fmt.Errorf("no type found for object %v", s))
+	// OpenAPI v3 schemas may list the alternative types instead
+	alternatives := s.OneOf
+	if len(alternatives) == 0 {
+		alternatives = s.AnyOf
+	}
+	if len(alternatives) > 0 {
+		var names []string
+		for _, o := range alternatives {
+			name, err := GetTypeName(o)
+			if err != nil {
+				return "", err
+			}
+			names = append(names, name)
+		}
+		return strings.Join(names, " or "), nil
+	}
+	return "", fmt.Errorf("no type found for object %v", s)
 }
 
 // IsArray returns true if the type is an array type.
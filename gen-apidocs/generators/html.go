@@ -23,6 +23,7 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"regexp"
 	"sort"
 	"strings"
 	"time"
@@ -36,6 +37,9 @@ type TOCItem struct {
 	Link        string
 	File        string
 	SubSections []*TOCItem
+
+	// Page is the HTML page of the item in the multi-page mode.
+	Page string `json:",omitempty"`
 }
 
 func (ti *TOCItem) ToHTML() string {
@@ -323,7 +327,7 @@ func (h *HTMLWriter) WriteOperation(o *api.Operation) error {
 	nvg := o.ID
 	linkID := getLink(nvg)
 
-	oGroup, oVersion, oKind, _ := o.GetGroupVersionKindSub()
+	oGroup, oVersion, oKind, _ := o.GetGroupVersionKindSub(h.Config)
 	oApiVersion := api.ApiVersion(oVersion)
 
 	if len(oGroup) > 0 {
@@ -418,6 +422,8 @@ func (h *HTMLWriter) writeOperationSample(w io.Writer, req bool, op string, exam
 			msg = "<CODE>curl</CODE> command (<I>requires <code>kubectl proxy</code> to be running</I>)"
 		} else if eType == "kubectl" && strings.Contains(msg, "Command") { // `kubectl` command
 			msg = "<CODE>kubectl</CODE> command"
+		} else if eType == "go" && strings.Contains(msg, "client-go") { // Go code with `client-go`
+			msg = "Go code with <CODE>client-go</CODE>"
 		}
 		lType := strings.Split(e.Type, ":")[1]
 		lang := strings.Split(lType, "_")[1]
@@ -650,39 +656,7 @@ func (h *HTMLWriter) generateNavDataJS() error {
 }
 
 func (h *HTMLWriter) generateIndex(navContent string) error {
-	html, err := os.Create(filepath.Join(api.BuildDir, "index.html"))
-	if err != nil {
-		return err
-	}
-	defer html.Close()
-
-	/* Make sure the following stylesheets exist in kubernetes/website repo:
-	   kubernetes/website/static/css/bootstrap-5.3.2.min.css
-	   kubernetes/website/static/css/fontawesome-4.7.0.min.css
-	   kubernetes/website/static/css/style_apiref.css
-	*/
-	fmt.Fprintf(html, "<!DOCTYPE html>\n<HTML lang=\"en\">\n<HEAD>\n<META charset=\"UTF-8\">\n")
-	fmt.Fprintf(html, "<TITLE>%s</TITLE>\n", h.TOC.Title)
-	fmt.Fprintf(html, "<LINK rel=\"shortcut icon\" href=\"favicon.ico\" type=\"image/vnd.microsoft.icon\">\n")
-	fmt.Fprintf(html, "<LINK rel=\"stylesheet\" href=\"/css/bootstrap-5.3.2.min.css\" type=\"text/css\">\n")
-	fmt.Fprintf(html, "<LINK rel=\"stylesheet\" href=\"/css/fontawesome-4.7.0.min.css\" type=\"text/css\">\n")
-	fmt.Fprintf(html, "<LINK rel=\"stylesheet\" href=\"/css/style_apiref.css\" type=\"text/css\">\n")
-	fmt.Fprintf(html, "</HEAD>\n<BODY class=\"theme-auto\">\n")
-	fmt.Fprintf(html, "<DIV id=\"wrapper\" class=\"container-fluid\">\n")
-	fmt.Fprintf(html, "<DIV class=\"row\">\n")
-	fmt.Fprintf(html, "<DIV id=\"sidebar-wrapper\" class=\"col-xs-4 col-sm-3 col-md-2 side-nav side-bar-nav\">\n")
-
-	// html buffer
-	buf := "<DIV class=\"row\">\n  <DIV class=\"col-md-6 copyright\">\n " + h.TOC.Copyright + "\n  </DIV>\n"
-	buf += "  <DIV class=\"col-md-6 text-right\">\n"
-	buf += fmt.Sprintf("    <DIV>Generated at: %s</DIV>\n", time.Now().Format("2006-01-02 15:04:05 (MST)"))
-	pos := strings.LastIndex(h.Config.SpecVersion, ".")
-	release := fmt.Sprintf("release-%s", h.Config.SpecVersion[1:pos])
-	spec_link := "https://github.com/kubernetes/kubernetes/blob/" + release + "/api/openapi-spec/swagger.json"
-	buf += "  <DIV>"
-	buf += fmt.Sprintf("API Version: <a href=\"%s\">%s</a>\n", spec_link, h.Config.SpecVersion)
-	buf += "<A href=\"#\" class=\"btn btn-info btn-sm switch-theme\">Switch <I class=\"fa fa-sun-o\"></I>/<I class=\"fa fa-moon-o\"></I></A>\n"
-	buf += "  </DIV>\n</DIV>\n</DIV>"
+	buf := h.pageHeader()
 	const OK = "\033[32mOK\033[0m"
 	const NOT_FOUND = "\033[31mNot found\033[0m"
 	for _, sec := range h.TOC.Sections {
@@ -722,6 +696,49 @@ func (h *HTMLWriter) generateIndex(navContent string) error {
 		}
 	}
 
+	return h.writePage(filepath.Join(api.BuildDir, "index.html"), h.TOC.Title, navContent, buf)
+}
+
+// pageHeader returns the copyright and version information shown at the top
+// of the page content.
+func (h *HTMLWriter) pageHeader() string {
+	buf := "<DIV class=\"row\">\n  <DIV class=\"col-md-6 copyright\">\n " + h.TOC.Copyright + "\n  </DIV>\n"
+	buf += "  <DIV class=\"col-md-6 text-right\">\n"
+	buf += fmt.Sprintf("    <DIV>Generated at: %s</DIV>\n", time.Now().Format("2006-01-02 15:04:05 (MST)"))
+	pos := strings.LastIndex(h.Config.SpecVersion, ".")
+	release := fmt.Sprintf("release-%s", h.Config.SpecVersion[1:pos])
+	spec_link := "https://github.com/kubernetes/kubernetes/blob/" + release + "/api/openapi-spec/swagger.json"
+	buf += "  <DIV>"
+	buf += fmt.Sprintf("API Version: <a href=\"%s\">%s</a>\n", spec_link, h.Config.SpecVersion)
+	buf += "<A href=\"#\" class=\"btn btn-info btn-sm switch-theme\">Switch <I class=\"fa fa-sun-o\"></I>/<I class=\"fa fa-moon-o\"></I></A>\n"
+	buf += "  </DIV>\n</DIV>\n</DIV>"
+	return buf
+}
+
+// writePage writes an HTML page with the navigation and the content.
+func (h *HTMLWriter) writePage(path, title, navContent, content string) error {
+	html, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer html.Close()
+
+	/* Make sure the following stylesheets exist in kubernetes/website repo:
+	   kubernetes/website/static/css/bootstrap-5.3.2.min.css
+	   kubernetes/website/static/css/fontawesome-4.7.0.min.css
+	   kubernetes/website/static/css/style_apiref.css
+	*/
+	fmt.Fprintf(html, "<!DOCTYPE html>\n<HTML lang=\"en\">\n<HEAD>\n<META charset=\"UTF-8\">\n")
+	fmt.Fprintf(html, "<TITLE>%s</TITLE>\n", title)
+	fmt.Fprintf(html, "<LINK rel=\"shortcut icon\" href=\"favicon.ico\" type=\"image/vnd.microsoft.icon\">\n")
+	fmt.Fprintf(html, "<LINK rel=\"stylesheet\" href=\"/css/bootstrap-5.3.2.min.css\" type=\"text/css\">\n")
+	fmt.Fprintf(html, "<LINK rel=\"stylesheet\" href=\"/css/fontawesome-4.7.0.min.css\" type=\"text/css\">\n")
+	fmt.Fprintf(html, "<LINK rel=\"stylesheet\" href=\"/css/style_apiref.css\" type=\"text/css\">\n")
+	fmt.Fprintf(html, "</HEAD>\n<BODY class=\"theme-auto\">\n")
+	fmt.Fprintf(html, "<DIV id=\"wrapper\" class=\"container-fluid\">\n")
+	fmt.Fprintf(html, "<DIV class=\"row\">\n")
+	fmt.Fprintf(html, "<DIV id=\"sidebar-wrapper\" class=\"col-xs-4 col-sm-3 col-md-2 side-nav side-bar-nav\">\n")
+
 	/*
 		Make sure the following scripts exist in kubernetes/website repo:
 		kubernetes/website/static/js/jquery-3.6.0.min.js
@@ -731,7 +748,7 @@ func (h *HTMLWriter) generateIndex(navContent string) error {
 	*/
 	fmt.Fprintf(html, "%s</DIV>\n", navContent)
 	fmt.Fprintf(html, "<DIV id=\"page-content-wrapper\" class=\"col-xs-8 offset-xs-4 col-sm-9 offset-sm-3 col-md-10 offset-md-2 body-content\">\n")
-	fmt.Fprintf(html, "%s", string(buf))
+	fmt.Fprintf(html, "%s", content)
 	fmt.Fprintf(html, "\n</DIV>\n</DIV>\n</DIV>\n")
 	fmt.Fprintf(html, "<SCRIPT src=\"/js/jquery-3.6.0.min.js\"></SCRIPT>\n")
 	fmt.Fprintf(html, "<SCRIPT src=\"/js/jquery.scrollTo-2.1.3.min.js\"></SCRIPT>\n")
@@ -742,15 +759,216 @@ func (h *HTMLWriter) generateIndex(navContent string) error {
 	return nil
 }
 
+// htmlPage is a page written in the multi-page mode.
+type htmlPage struct {
+	// Item is the TOC item of the page and Section is its top level section.
+	Item    *TOCItem
+	Section *TOCItem
+	// File is the name of the page in the build directory.
+	File string
+	// Includes are the include files making the content of the page.
+	Includes []string
+}
+
+var (
+	htmlAnchorID  = regexp.MustCompile(`\bid="([^"]+)"`)
+	htmlAnchorRef = regexp.MustCompile(`href="#([^"]+)"`)
+	htmlTag       = regexp.MustCompile(`<[^>]*>`)
+)
+
+// isResourceItem tests if a TOC item is written by WriteResource.
+func isResourceItem(item *TOCItem) bool {
+	return item.Level == 2 && strings.HasSuffix(item.File, "_concept.html")
+}
+
+// setPage sets the page of a TOC item and of its subsections.
+func setPage(item *TOCItem, file string) {
+	item.Page = file
+	for _, sub := range item.SubSections {
+		setPage(sub, file)
+	}
+}
+
+// pages splits the TOC into pages: one page for each top level section,
+// with the definitions and operations in it, and one page for each resource.
+// The first section is written to index.html. A resource listed in several
+// sections has a single page, in the first one. Two different items with links
+// mapping to the same file are reported, rather than one page overwriting the
+// other.
+func (h *HTMLWriter) pages() ([]*htmlPage, error) {
+	var pages []*htmlPage
+	files := map[string]*htmlPage{}
+	addPage := func(page *htmlPage) (*htmlPage, error) {
+		if other, ok := files[page.File]; ok {
+			if other.Item.File == page.Item.File {
+				return other, nil
+			}
+			title := func(item *TOCItem) string {
+				return strings.TrimSpace(htmlTag.ReplaceAllString(item.Title, ""))
+			}
+			return nil, fmt.Errorf("%q and %q would both be written to %s", title(other.Item), title(page.Item), page.File)
+		}
+		files[page.File] = page
+		pages = append(pages, page)
+		return page, nil
+	}
+
+	for i, sec := range h.TOC.Sections {
+		file := sec.Link + ".html"
+		if i == 0 {
+			file = "index.html"
+		}
+		secPage, err := addPage(&htmlPage{Item: sec, Section: sec, File: file, Includes: []string{sec.File}})
+		if err != nil {
+			return nil, err
+		}
+		sec.Page = file
+
+		for _, sub := range sec.SubSections {
+			if isResourceItem(sub) {
+				page, err := addPage(&htmlPage{Item: sub, Section: sec, File: sub.Link + ".html", Includes: []string{sub.File}})
+				if err != nil {
+					return nil, err
+				}
+				setPage(sub, page.File)
+				continue
+			}
+			if len(sub.File) > 0 {
+				secPage.Includes = append(secPage.Includes, sub.File)
+			}
+			setPage(sub, file)
+		}
+	}
+	return pages, nil
+}
+
+// pageNav returns the navigation of a page. All the top level sections are
+// listed, but only the items of the current section are expanded.
+func (h *HTMLWriter) pageNav(page *htmlPage) string {
+	nav := "<UL id=\"navigation\">\n"
+	for _, sec := range h.TOC.Sections {
+		item := *sec
+		item.SubSections = nil
+		if sec == page.Section {
+			for _, sub := range sec.SubSections {
+				subItem := *sub
+				if sub != page.Item {
+					subItem.SubSections = nil
+				}
+				item.SubSections = append(item.SubSections, &subItem)
+			}
+		}
+		nav += item.ToHTML()
+		nav += "\n"
+	}
+	nav += "</UL>\n"
+	return nav
+}
+
+// addAnchors records the page of the anchors found in its content. An anchor
+// found on several pages, e.g. the ID of a definition inlined in several
+// resources, belongs to the first page.
+func addAnchors(anchors map[string]string, content, file string) {
+	for _, m := range htmlAnchorID.FindAllStringSubmatch(content, -1) {
+		if _, ok := anchors[m[1]]; !ok {
+			anchors[m[1]] = file
+		}
+	}
+}
+
+// rewriteLinks points the links to the anchors found on other pages to
+// these pages.
+func rewriteLinks(content, file string, anchors map[string]string) string {
+	return htmlAnchorRef.ReplaceAllStringFunc(content, func(ref string) string {
+		id := htmlAnchorRef.FindStringSubmatch(ref)[1]
+		if page, ok := anchors[id]; ok && page != file {
+			return fmt.Sprintf(`href="%s#%s"`, page, id)
+		}
+		return ref
+	})
+}
+
+// generatePages writes a page for each top level section and resource, with
+// the links across the pages rewritten.
+func (h *HTMLWriter) generatePages() error {
+	pages, err := h.pages()
+	if err != nil {
+		return err
+	}
+
+	contents := make([]string, len(pages))
+	anchors := map[string]string{}
+	for i, page := range pages {
+		buf := ""
+		for _, fn := range page.Includes {
+			fmt.Printf("Collecting %s ... ", fn)
+			content, err := os.ReadFile(filepath.Join(api.IncludesDir, fn))
+			if err != nil {
+				fmt.Println("\033[31mNot found\033[0m")
+				continue
+			}
+			buf += string(content)
+			fmt.Println("\033[32mOK\033[0m")
+		}
+		// the resources of a category are listed on the category page
+		if page.Item == page.Section {
+			list := ""
+			for _, sub := range page.Section.SubSections {
+				if isResourceItem(sub) {
+					list += fmt.Sprintf("  <LI><A href=\"#%s\">%s</A></LI>\n", sub.Link, sub.Title)
+				}
+			}
+			if list != "" {
+				buf += "<UL class=\"resource-list\">\n" + list + "</UL>\n"
+			}
+		}
+		contents[i] = buf
+		addAnchors(anchors, buf, page.File)
+	}
+
+	// the links in the navigation point to the TOC items
+	var addItems func(items []*TOCItem)
+	addItems = func(items []*TOCItem) {
+		for _, item := range items {
+			if _, ok := anchors[item.Link]; !ok {
+				anchors[item.Link] = item.Page
+			}
+			addItems(item.SubSections)
+		}
+	}
+	addItems(h.TOC.Sections)
+
+	header := h.pageHeader()
+	for i, page := range pages {
+		title := h.TOC.Title
+		if i > 0 {
+			title = strings.TrimSpace(htmlTag.ReplaceAllString(page.Item.Title, "")) + " - " + h.TOC.Title
+		}
+		nav := rewriteLinks(h.pageNav(page), page.File, anchors)
+		content := rewriteLinks(header+contents[i], page.File, anchors)
+		if err := h.writePage(filepath.Join(api.BuildDir, page.File), title, nav, content); err != nil {
+			return fmt.Errorf("failed to write page '%s': %w", page.File, err)
+		}
+	}
+
+	return nil
+}
+
 func (h *HTMLWriter) Finalize() error {
 	if err := os.MkdirAll(api.BuildDir, os.ModePerm); err != nil {
 		return err
 	}
 
-	navContent := h.generateNavContent()
+	if *MultiPage {
+		if err := h.generatePages(); err != nil {
+			return err
+		}
+	} else {
+		navContent := h.generateNavContent()
 
-	if err := h.generateIndex(navContent); err != nil {
-		return err
+		if err := h.generateIndex(navContent); err != nil {
+			return err
+		}
 	}
 
 	if err := h.generateNavDataJS(); err != nil {
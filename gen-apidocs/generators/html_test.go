@@ -0,0 +1,200 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generators
+
+import (
+	"reflect"
+	"testing"
+)
+
+// testTOC returns a TOC with an overview, a category with a resource and a
+// definition, and a section for the definitions.
+func testTOC() TOC {
+	operation := &TOCItem{Level: 3, Title: "Read Operations", Link: "read-operations-widget-v1-example"}
+	resource := &TOCItem{
+		Level:       2,
+		Title:       "Widget v1 example",
+		Link:        "widget-v1-example",
+		File:        "_generated_widget_v1_example_concept.html",
+		SubSections: []*TOCItem{operation},
+	}
+	inlined := &TOCItem{Level: 2, Title: "WidgetStatus v1 example", Link: "widgetstatus-v1-example", File: "_generated_widgetstatus_v1_example_definition.html"}
+	return TOC{
+		Title: "Kubernetes API",
+		Sections: []*TOCItem{
+			{Level: 1, Title: "Overview", Link: "api-overview", File: "_overview.html"},
+			{Level: 1, Title: "Workloads", Link: "workloads", File: "_workloads.html", SubSections: []*TOCItem{resource, inlined}},
+			{Level: 1, Title: "Definitions", Link: "definitions", File: "_definitions.html"},
+		},
+	}
+}
+
+func TestPages(t *testing.T) {
+	h := &HTMLWriter{TOC: testTOC()}
+	pages, err := h.pages()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	type page struct {
+		File     string
+		Section  string
+		Includes []string
+	}
+	var actual []page
+	for _, p := range pages {
+		actual = append(actual, page{File: p.File, Section: p.Section.Link, Includes: p.Includes})
+	}
+	expected := []page{
+		{File: "index.html", Section: "api-overview", Includes: []string{"_overview.html"}},
+		{File: "workloads.html", Section: "workloads", Includes: []string{"_workloads.html", "_generated_widgetstatus_v1_example_definition.html"}},
+		{File: "widget-v1-example.html", Section: "workloads", Includes: []string{"_generated_widget_v1_example_concept.html"}},
+		{File: "definitions.html", Section: "definitions", Includes: []string{"_definitions.html"}},
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("got pages %+v, expected %+v", actual, expected)
+	}
+
+	workloads := h.TOC.Sections[1]
+	resource, inlined := workloads.SubSections[0], workloads.SubSections[1]
+	for _, test := range []struct {
+		Item *TOCItem
+		Page string
+	}{
+		{workloads, "workloads.html"},
+		{resource, "widget-v1-example.html"},
+		{resource.SubSections[0], "widget-v1-example.html"},
+		{inlined, "workloads.html"},
+	} {
+		if test.Item.Page != test.Page {
+			t.Errorf("%s: got page %q, expected %q", test.Item.Title, test.Item.Page, test.Page)
+		}
+	}
+}
+
+func TestPagesSharedResource(t *testing.T) {
+	toc := testTOC()
+	resource := toc.Sections[1].SubSections[0]
+	shared := *resource
+	toc.Sections[2].SubSections = []*TOCItem{&shared}
+	h := &HTMLWriter{TOC: toc}
+	pages, err := h.pages()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	count := 0
+	for _, p := range pages {
+		if p.File == "widget-v1-example.html" {
+			count++
+			if p.Section != toc.Sections[1] {
+				t.Errorf("expected the page of the resource to be in the first section")
+			}
+		}
+	}
+	if count != 1 {
+		t.Errorf("got %d pages for the resource, expected 1", count)
+	}
+	if shared.Page != "widget-v1-example.html" {
+		t.Errorf("got page %q for the resource in the second section", shared.Page)
+	}
+}
+
+func TestPagesDuplicateFiles(t *testing.T) {
+	tests := []struct {
+		Name   string
+		Modify func(toc *TOC)
+	}{
+		{
+			Name: "section and resource",
+			Modify: func(toc *TOC) {
+				toc.Sections[2].Link = "widget-v1-example"
+			},
+		},
+		{
+			Name: "section and index",
+			Modify: func(toc *TOC) {
+				toc.Sections[2].Link = "index"
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			toc := testTOC()
+			test.Modify(&toc)
+			h := &HTMLWriter{TOC: toc}
+			if _, err := h.pages(); err == nil {
+				t.Errorf("expected an error for the duplicate page files")
+			}
+		})
+	}
+}
+
+func TestRewriteLinks(t *testing.T) {
+	anchors := map[string]string{}
+	addAnchors(anchors, `<DIV id="pod-v1-core"></DIV><DIV id="podspec-v1-core"></DIV>`, "pod-v1-core.html")
+	// the inlined definition found again on a later page still belongs to the first one
+	addAnchors(anchors, `<DIV id="deployment-v1-apps"></DIV><DIV id="podspec-v1-core"></DIV>`, "deployment-v1-apps.html")
+
+	expected := map[string]string{
+		"pod-v1-core":        "pod-v1-core.html",
+		"podspec-v1-core":    "pod-v1-core.html",
+		"deployment-v1-apps": "deployment-v1-apps.html",
+	}
+	if !reflect.DeepEqual(anchors, expected) {
+		t.Errorf("got anchors %v, expected %v", anchors, expected)
+	}
+
+	tests := []struct {
+		Name     string
+		Content  string
+		File     string
+		Expected string
+	}{
+		{
+			Name:     "anchor on another page",
+			Content:  `<A href="#podspec-v1-core">PodSpec</A>`,
+			File:     "deployment-v1-apps.html",
+			Expected: `<A href="pod-v1-core.html#podspec-v1-core">PodSpec</A>`,
+		},
+		{
+			Name:     "anchor on the same page",
+			Content:  `<A href="#podspec-v1-core">PodSpec</A>`,
+			File:     "pod-v1-core.html",
+			Expected: `<A href="#podspec-v1-core">PodSpec</A>`,
+		},
+		{
+			Name:     "unknown anchor",
+			Content:  `<A href="#missing">Missing</A>`,
+			File:     "pod-v1-core.html",
+			Expected: `<A href="#missing">Missing</A>`,
+		},
+		{
+			Name:     "external link",
+			Content:  `<A href="https://kubernetes.io/#pod-v1-core">Docs</A>`,
+			File:     "deployment-v1-apps.html",
+			Expected: `<A href="https://kubernetes.io/#pod-v1-core">Docs</A>`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			if actual := rewriteLinks(test.Content, test.File, anchors); actual != test.Expected {
+				t.Errorf("got %s, expected %s", actual, test.Expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,563 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generators
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/kubernetes-sigs/reference-docs/gen-apidocs/generators/api"
+)
+
+// MarkdownWriter writes the docs as Hugo pages, one page per top level
+// section of the TOC. The first section becomes the _index.md page of the
+// Hugo section, the others are written to <link>.md next to it.
+type MarkdownWriter struct {
+	Config *api.Config
+	TOC    TOC
+
+	// currentTOCItem is used to remember the current item between
+	// calls to e.g. WriteResourceCategory() followed by WriteResource().
+	currentTOCItem *TOCItem
+
+	// anchors maps the IDs of the headings to the top level section they
+	// are written to, so that links across pages can be resolved.
+	anchors map[string]*TOCItem
+}
+
+func NewMarkdownWriter(config *api.Config, copyright, title string) DocWriter {
+	writer := MarkdownWriter{
+		Config: config,
+		TOC: TOC{
+			Copyright: copyright,
+			Title:     title,
+			Sections:  []*TOCItem{},
+		},
+		anchors: map[string]*TOCItem{},
+	}
+	return &writer
+}
+
+func (m *MarkdownWriter) Extension() string {
+	return ".md"
+}
+
+func (m *MarkdownWriter) DefaultStaticContent(title string) string {
+	titleID := strings.ToLower(strings.ReplaceAll(title, " ", "-"))
+	return fmt.Sprintf("# %s {#%s}\n", title, titleID)
+}
+
+// sectionHeading matches the heading of an HTML section file.
+var sectionHeading = regexp.MustCompile(`(?is)<h1[^>]*>.*?</h1>\s*`)
+
+// htmlSection returns the content of the HTML section file matching a
+// markdown one, without its heading, or an empty string if there is none.
+// Hugo renders the inline HTML, so the HTML sections can be used for the
+// sections that have no markdown file.
+func htmlSection(file string) (string, error) {
+	src := filepath.Join(api.SectionsDir, strings.TrimSuffix(file, ".md")+".html")
+	content, err := os.ReadFile(src)
+	if os.IsNotExist(err) {
+		return "", nil
+	} else if err != nil {
+		return "", fmt.Errorf("failed to read section file %s: %w", src, err)
+	}
+	if loc := sectionHeading.FindIndex(content); loc != nil {
+		content = append(content[:loc[0]], content[loc[1]:]...)
+	}
+	return string(content), nil
+}
+
+// addSection starts a new top level section. The section title is the page
+// title, so the heading of the HTML section file used by default is dropped.
+func (m *MarkdownWriter) addSection(title, link, file string) error {
+	content, err := htmlSection(file)
+	if err != nil {
+		return err
+	}
+	if err := writeStaticFile(file, content); err != nil {
+		return err
+	}
+
+	item := TOCItem{
+		Level: 1,
+		Title: title,
+		Link:  link,
+		File:  file,
+	}
+	m.TOC.Sections = append(m.TOC.Sections, &item)
+	m.currentTOCItem = &item
+	m.anchors[link] = &item
+
+	return nil
+}
+
+// addAnchor records the section of a heading ID.
+func (m *MarkdownWriter) addAnchor(id string) {
+	m.anchors[id] = m.TOC.Sections[len(m.TOC.Sections)-1]
+}
+
+func (m *MarkdownWriter) WriteOverview() error {
+	return m.addSection("Overview", "api-overview", "_overview.md")
+}
+
+func (m *MarkdownWriter) WriteAPIGroupVersions(gvs api.GroupVersions) error {
+	fn := "_group_versions.md"
+	path := filepath.Join(api.IncludesDir, fn)
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	fmt.Fprint(f, "The API Groups and their versions are summarized in the following table.\n\n")
+	fmt.Fprint(f, "| Group | Versions |\n| --- | --- |\n")
+
+	groups := api.ApiGroups{}
+	for group := range gvs {
+		groups = append(groups, api.ApiGroup(group))
+	}
+	sort.Sort(groups)
+
+	for _, group := range groups {
+		versionList := gvs[group.String()]
+		sort.Sort(versionList)
+		var versions []string
+		for _, v := range versionList {
+			versions = append(versions, v.String())
+		}
+		fmt.Fprintf(f, "| `%s` | `%s` |\n", group, strings.Join(versions, ", "))
+	}
+
+	item := TOCItem{
+		Level: 1,
+		Title: "API Groups",
+		Link:  "api-groups",
+		File:  fn,
+	}
+	m.TOC.Sections = append(m.TOC.Sections, &item)
+	m.currentTOCItem = &item
+	m.anchors[item.Link] = &item
+
+	return nil
+}
+
+func (m *MarkdownWriter) WriteResourceCategory(name, file string) error {
+	link := strings.ReplaceAll(strings.ToLower(name), " ", "-")
+	return m.addSection(name, link, "_"+file+".md")
+}
+
+func (m *MarkdownWriter) WriteDefinitionsOverview() error {
+	return m.addSection("Definitions", "definitions", "_definitions.md")
+}
+
+func (m *MarkdownWriter) WriteOrphanedOperationsOverview() error {
+	return m.addSection("Operations", "operations", "_operations.md")
+}
+
+func (m *MarkdownWriter) WriteOldVersionsOverview() error {
+	return m.addSection("Old API Versions", "old-api-versions", "_oldversions.md")
+}
+
+// mdCell escapes a text to be used in a table cell.
+func mdCell(s string) string {
+	s = strings.ReplaceAll(strings.TrimSpace(s), "|", "\\|")
+	return strings.ReplaceAll(s, "\n", "<br>")
+}
+
+// mdTypeLink returns the type of a field with a link to its definition. The
+// brackets of the slice and map types are escaped so that they are not taken
+// for a link.
+func mdTypeLink(f api.Field) string {
+	t := strings.NewReplacer("[", "\\[", "]", "\\]").Replace(f.Type)
+	if f.Definition != nil {
+		return strings.ReplaceAll(t, f.Definition.Name, f.Definition.MdLink())
+	}
+	return t
+}
+
+func (m *MarkdownWriter) writeGVK(w io.Writer, group string, version api.ApiVersion, kind string) {
+	fmt.Fprint(w, "| Group | Version | Kind |\n| --- | --- | --- |\n")
+	fmt.Fprintf(w, "| `%s` | `%s` | `%s` |\n\n", group, version, kind)
+}
+
+func (m *MarkdownWriter) writeOtherVersions(w io.Writer, d *api.Definition) {
+	if d.OtherVersions.Len() == 0 {
+		return
+	}
+
+	var links []string
+	for _, v := range d.OtherVersions {
+		groupName := strings.ReplaceAll(strings.ToLower(v.GroupFullName), ".", "-")
+		links = append(links, fmt.Sprintf("[%s](#%s-%s-%s)", v.Version, strings.ToLower(v.Name), v.Version, groupName))
+	}
+	fmt.Fprintf(w, "> Other API versions of this object exist: %s\n\n", strings.Join(links, " "))
+}
+
+func (m *MarkdownWriter) writeAppearsIn(w io.Writer, d *api.Definition) {
+	if d.AppearsIn.Len() == 0 {
+		return
+	}
+
+	fmt.Fprint(w, "Appears In:\n\n")
+	for _, a := range d.AppearsIn {
+		groupName := strings.ReplaceAll(strings.ToLower(a.GroupFullName), ".", "-")
+		fmt.Fprintf(w, "- [%s \\[%s/%s\\]](#%s-%s-%s)\n", a.Name, a.Group, a.Version,
+			strings.ToLower(a.Name), a.Version, groupName)
+	}
+	fmt.Fprint(w, "\n")
+}
+
+func (m *MarkdownWriter) writeFields(w io.Writer, d *api.Definition) {
+	if d.Fields.Len() == 0 {
+		return
+	}
+
+	fmt.Fprint(w, "| Field | Description |\n| --- | --- |\n")
+	for _, field := range d.Fields {
+		fmt.Fprintf(w, "| `%s`", field.Name)
+		if field.Type != "" {
+			fmt.Fprintf(w, "<br>*%s*", mdTypeLink(*field))
+		}
+		if field.PatchStrategy != "" {
+			fmt.Fprintf(w, "<br>**patch strategy**: *%s*", field.PatchStrategy)
+		}
+		if field.PatchMergeKey != "" {
+			fmt.Fprintf(w, "<br>**patch merge key**: *%s*", field.PatchMergeKey)
+		}
+		fmt.Fprintf(w, " | %s |\n", mdCell(field.DescriptionWithEntities))
+	}
+	fmt.Fprint(w, "\n")
+}
+
+// writeExamples writes the examples as code blocks, each with its message
+// as a title.
+func (m *MarkdownWriter) writeExamples(w io.Writer, examples []api.ExampleText) {
+	for _, e := range examples {
+		if strings.TrimSpace(e.Text) == "" {
+			continue
+		}
+		lType := strings.Split(e.Type, ":")[1]
+		lang := strings.Split(lType, "_")[1]
+		if e.Msg != "" {
+			fmt.Fprintf(w, "%s\n\n", e.Msg)
+		}
+		fmt.Fprintf(w, "```%s\n%s\n```\n\n", lang, strings.TrimSpace(e.Text))
+	}
+}
+
+func (m *MarkdownWriter) writeSamples(w io.Writer, d *api.Definition) {
+	if d.Sample.Sample == "" {
+		return
+	}
+
+	if d.Sample.Note != "" {
+		fmt.Fprintf(w, "%s\n\n", d.Sample.Note)
+	}
+	m.writeExamples(w, d.GetSamples())
+}
+
+func (m *MarkdownWriter) writeParams(w io.Writer, level int, title string, params api.Fields) {
+	fmt.Fprintf(w, "%s %s\n\n", strings.Repeat("#", level), title)
+	fmt.Fprint(w, "| Parameter | Description |\n| --- | --- |\n")
+	for _, p := range params {
+		fmt.Fprintf(w, "| `%s`", p.Name)
+		if p.Type != "" {
+			fmt.Fprintf(w, "<br>*%s*", mdTypeLink(*p))
+		}
+		fmt.Fprintf(w, " | %s |\n", mdCell(p.DescriptionWithEntities))
+	}
+	fmt.Fprint(w, "\n")
+}
+
+func (m *MarkdownWriter) writeResponseParams(w io.Writer, level int, o *api.Operation) {
+	if o.HttpResponses.Len() == 0 {
+		return
+	}
+
+	fmt.Fprintf(w, "%s Response\n\n", strings.Repeat("#", level))
+	fmt.Fprint(w, "| Code | Description |\n| --- | --- |\n")
+	responses := o.HttpResponses
+	sort.Slice(responses, func(i, j int) bool {
+		return strings.Compare(responses[i].Name, responses[j].Name) < 0
+	})
+	for _, p := range responses {
+		fmt.Fprintf(w, "| %s", p.Name)
+		if p.Field.Type != "" {
+			fmt.Fprintf(w, "<br>*%s*", mdTypeLink(p.Field))
+		}
+		fmt.Fprintf(w, " | %s |\n", mdCell(p.Field.DescriptionWithEntities))
+	}
+	fmt.Fprint(w, "\n")
+}
+
+// WriteOperationBody writes the examples, the HTTP request and the parameters
+// of an operation, using headings one level below the operation heading.
+func (m *MarkdownWriter) WriteOperationBody(w io.Writer, level int, o *api.Operation) {
+	if o.Definition != nil {
+		m.writeExamples(w, o.GetExampleRequests())
+		m.writeExamples(w, o.GetExampleResponses())
+	}
+
+	if desc := strings.TrimSpace(o.Description()); desc != "" {
+		fmt.Fprintf(w, "%s\n\n", desc)
+	}
+	fmt.Fprintf(w, "%s HTTP Request\n\n", strings.Repeat("#", level))
+	fmt.Fprintf(w, "`%s`\n\n", o.GetDisplayHttp())
+
+	if o.PathParams.Len() > 0 {
+		m.writeParams(w, level, "Path Parameters", o.PathParams)
+	}
+	if o.QueryParams.Len() > 0 {
+		m.writeParams(w, level, "Query Parameters", o.QueryParams)
+	}
+	if o.BodyParams.Len() > 0 {
+		m.writeParams(w, level, "Body Parameters", o.BodyParams)
+	}
+	m.writeResponseParams(w, level, o)
+}
+
+func (m *MarkdownWriter) WriteDefinition(d *api.Definition) error {
+	fn := "_" + definitionFileName(d) + ".md"
+	path := filepath.Join(api.IncludesDir, fn)
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	nvg := fmt.Sprintf("%s %s %s", d.Name, d.Version, d.GroupDisplayName())
+	linkID := getLink(nvg)
+
+	fmt.Fprintf(f, "## %s {#%s}\n\n", nvg, linkID)
+	m.writeGVK(f, d.GroupDisplayName(), d.Version, d.Name)
+
+	if desc := strings.TrimSpace(d.DescriptionWithEntities); desc != "" {
+		fmt.Fprintf(f, "%s\n\n", desc)
+	}
+	m.writeOtherVersions(f, d)
+	m.writeAppearsIn(f, d)
+	m.writeFields(f, d)
+
+	item := TOCItem{
+		Level: 2,
+		Title: nvg,
+		Link:  linkID,
+		File:  fn,
+	}
+	m.currentTOCItem.SubSections = append(m.currentTOCItem.SubSections, &item)
+	m.addAnchor(linkID)
+
+	return nil
+}
+
+func (m *MarkdownWriter) WriteOperation(o *api.Operation) error {
+	fn := "_" + operationFileName(o) + ".md"
+	path := filepath.Join(api.IncludesDir, fn)
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	nvg := o.ID
+	linkID := getLink(nvg)
+
+	fmt.Fprintf(f, "## %s {#%s}\n\n", nvg, linkID)
+
+	item := TOCItem{
+		Level: 2,
+		Title: nvg,
+		Link:  linkID,
+		File:  fn,
+	}
+	m.currentTOCItem.SubSections = append(m.currentTOCItem.SubSections, &item)
+	m.addAnchor(linkID)
+
+	m.WriteOperationBody(f, 3, o)
+
+	return nil
+}
+
+func (m *MarkdownWriter) WriteResource(r *api.Resource) error {
+	fn := "_" + conceptFileName(r.Definition) + ".md"
+	path := filepath.Join(api.IncludesDir, fn)
+	w, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer w.Close()
+
+	dvg := fmt.Sprintf("%s %s %s", r.Name, r.Definition.Version, r.Definition.GroupDisplayName())
+	linkID := getLink(dvg)
+
+	fmt.Fprintf(w, "## %s {#%s}\n\n", dvg, linkID)
+
+	m.writeSamples(w, r.Definition)
+	m.writeGVK(w, r.Definition.GroupDisplayName(), r.Definition.Version, r.Name)
+
+	if r.DescriptionWarning != "" {
+		fmt.Fprintf(w, "> **Warning:** %s\n\n", r.DescriptionWarning)
+	}
+	if r.DescriptionNote != "" {
+		fmt.Fprintf(w, "> **Note:** %s\n\n", r.DescriptionNote)
+	}
+
+	m.writeOtherVersions(w, r.Definition)
+	m.writeAppearsIn(w, r.Definition)
+	m.writeFields(w, r.Definition)
+
+	resourceItem := TOCItem{
+		Level: 2,
+		Title: dvg,
+		Link:  linkID,
+		File:  fn,
+	}
+	m.currentTOCItem.SubSections = append(m.currentTOCItem.SubSections, &resourceItem)
+	m.addAnchor(linkID)
+
+	// Inline
+	for _, d := range r.Definition.Inline {
+		fmt.Fprintf(w, "### %s %s %s {#%s}\n\n", d.Name, d.Version, d.Group, d.LinkID())
+		m.addAnchor(d.LinkID())
+		m.writeAppearsIn(w, d)
+		m.writeFields(w, d)
+	}
+
+	// Operations
+	for _, oc := range r.Definition.OperationCategories {
+		if len(oc.Operations) == 0 {
+			continue
+		}
+
+		catID := strings.ReplaceAll(strings.ToLower(oc.Name), " ", "-") + "-" + r.Definition.LinkID()
+		fmt.Fprintf(w, "### %s {#%s}\n\n", oc.Name, catID)
+		m.addAnchor(catID)
+
+		ocItem := TOCItem{
+			Level: 3,
+			Title: oc.Name,
+			Link:  catID,
+		}
+		resourceItem.SubSections = append(resourceItem.SubSections, &ocItem)
+
+		for _, o := range oc.Operations {
+			opID := strings.ReplaceAll(strings.ToLower(o.Type.Name), " ", "-") + "-" + r.Definition.LinkID()
+			fmt.Fprintf(w, "#### %s {#%s}\n\n", o.Type.Name, opID)
+			m.addAnchor(opID)
+
+			opItem := TOCItem{
+				Level: 4,
+				Title: o.Type.Name,
+				Link:  opID,
+			}
+			ocItem.SubSections = append(ocItem.SubSections, &opItem)
+
+			m.WriteOperationBody(w, 5, o)
+		}
+	}
+
+	return nil
+}
+
+// pageName returns the name of the page of a top level section.
+func (m *MarkdownWriter) pageName(sec *TOCItem) string {
+	if len(m.TOC.Sections) > 0 && sec == m.TOC.Sections[0] {
+		return "_index.md"
+	}
+	return sec.Link + ".md"
+}
+
+var (
+	// mdAnchorLink matches the links to the headings, e.g. '](#pod-v1-core)'.
+	mdAnchorLink = regexp.MustCompile(`\]\(#([^)\s]+)\)`)
+
+	// htmlAnchorLink matches the HTML links to the headings found in the
+	// notes of the config, e.g. 'href="#pod-v1-core"'.
+	htmlAnchorLink = regexp.MustCompile(`(?i)href="#([^"\s]+)"`)
+)
+
+// resolveLinks rewrites the links to the headings found on other pages with
+// the Hugo 'ref' shortcode.
+func (m *MarkdownWriter) resolveLinks(content string, sec *TOCItem) string {
+	resolve := func(re *regexp.Regexp, format string) func(string) string {
+		return func(link string) string {
+			id := re.FindStringSubmatch(link)[1]
+			target, ok := m.anchors[id]
+			if !ok || target == sec {
+				return link
+			}
+			return fmt.Sprintf(format, m.pageName(target), id)
+		}
+	}
+	content = mdAnchorLink.ReplaceAllStringFunc(content, resolve(mdAnchorLink, `]({{< ref "%s#%s" >}})`))
+	return htmlAnchorLink.ReplaceAllStringFunc(content, resolve(htmlAnchorLink, `href="{{< ref "%s#%s" >}}"`))
+}
+
+// collectFiles appends the content of the include files of the TOC item and
+// its subsections.
+func collectFiles(buf *strings.Builder, item *TOCItem) {
+	if len(item.File) > 0 {
+		fmt.Printf("Collecting %s ... ", item.File)
+		content, err := os.ReadFile(filepath.Join(api.IncludesDir, item.File))
+		if err == nil {
+			buf.Write(content)
+			buf.WriteString("\n")
+			fmt.Println("\033[32mOK\033[0m")
+		} else {
+			fmt.Println("\033[31mNot found\033[0m")
+		}
+	}
+	for _, sub := range item.SubSections {
+		collectFiles(buf, sub)
+	}
+}
+
+// Finalize writes a page for each top level section. The pages are written
+// from the include files, so it is safe to call Finalize more than once.
+func (m *MarkdownWriter) Finalize() error {
+	if err := os.MkdirAll(api.BuildDir, os.ModePerm); err != nil {
+		return err
+	}
+
+	for i, sec := range m.TOC.Sections {
+		title := sec.Title
+		if i == 0 {
+			title = m.TOC.Title
+		}
+
+		var buf strings.Builder
+		fmt.Fprintf(&buf, "---\ntitle: %q\nweight: %d\nauto_generated: true\n---\n\n", title, i+1)
+		if i == 0 {
+			fmt.Fprintf(&buf, "API Version: `%s`\n\n", m.Config.SpecVersion)
+		}
+		collectFiles(&buf, sec)
+
+		path := filepath.Join(api.BuildDir, m.pageName(sec))
+		content := strings.TrimRight(m.resolveLinks(buf.String(), sec), "\n") + "\n"
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			return fmt.Errorf("failed to write page '%s': %w", path, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,189 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generators
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kubernetes-sigs/reference-docs/gen-apidocs/generators/api"
+)
+
+// setTestDirs points the output directories to a temporary directory, with
+// the given HTML section files.
+func setTestDirs(t *testing.T, sections map[string]string) {
+	t.Helper()
+	dir := t.TempDir()
+	buildDir, includesDir, sectionsDir := api.BuildDir, api.IncludesDir, api.SectionsDir
+	t.Cleanup(func() {
+		api.BuildDir, api.IncludesDir, api.SectionsDir = buildDir, includesDir, sectionsDir
+	})
+	api.BuildDir = filepath.Join(dir, "build")
+	api.IncludesDir = filepath.Join(api.BuildDir, "includes")
+	api.SectionsDir = filepath.Join(dir, "sections")
+	for _, d := range []string{api.IncludesDir, api.SectionsDir} {
+		if err := os.MkdirAll(d, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for name, content := range sections {
+		if err := os.WriteFile(filepath.Join(api.SectionsDir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// testWidget returns a resource with a field referencing a definition that
+// is written in another section.
+func testWidget() (*api.Resource, *api.Definition) {
+	spec := &api.Definition{
+		Name:                    "WidgetSpec",
+		Group:                   "example",
+		GroupFullName:           "example.k8s.io",
+		Version:                 "v1",
+		DescriptionWithEntities: "WidgetSpec is the specification of a Widget.",
+		Fields: api.Fields{
+			{Name: "size", Type: "integer", DescriptionWithEntities: "Size of the widget | in units."},
+			{Name: "tags", Type: "[]string", DescriptionWithEntities: "Tags of the widget.\nOptional."},
+		},
+	}
+	widget := &api.Definition{
+		Name:                    "Widget",
+		Group:                   "example",
+		GroupFullName:           "example.k8s.io",
+		Version:                 "v1",
+		DescriptionWithEntities: "Widget is an example resource.",
+		Fields: api.Fields{
+			{Name: "spec", Type: "WidgetSpec", Definition: spec, DescriptionWithEntities: "Spec of the widget."},
+		},
+	}
+	spec.AppearsIn = api.SortDefinitionsByName{widget}
+	return &api.Resource{Name: "Widget", Definition: widget}, spec
+}
+
+func TestMarkdownWriter(t *testing.T) {
+	setTestDirs(t, map[string]string{
+		"_overview.html": "<h1 id=\"api-overview\">API Overview</h1>\n<p>Welcome.</p>\n",
+	})
+	r, spec := testWidget()
+	config := &api.Config{SpecVersion: "v1.34.0"}
+
+	m := NewMarkdownWriter(config, "Copyright", "Kubernetes API")
+	steps := []func() error{
+		m.WriteOverview,
+		func() error { return m.WriteResourceCategory("Workloads", "workloads") },
+		func() error { return m.WriteResource(r) },
+		m.WriteDefinitionsOverview,
+		func() error { return m.WriteDefinition(spec) },
+		m.Finalize,
+	}
+	for _, step := range steps {
+		if err := step(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	read := func(name string) string {
+		t.Helper()
+		content, err := os.ReadFile(filepath.Join(api.BuildDir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(content)
+	}
+	tests := []struct {
+		Page     string
+		Expected []string
+		Missing  []string
+	}{
+		{
+			Page: "_index.md",
+			Expected: []string{
+				"---\ntitle: \"Kubernetes API\"\nweight: 1\n",
+				"API Version: `v1.34.0`",
+				"<p>Welcome.</p>",
+			},
+			// the heading of the HTML section is replaced by the page title
+			Missing: []string{"<h1", "API Overview"},
+		},
+		{
+			Page: "workloads.md",
+			Expected: []string{
+				"---\ntitle: \"Workloads\"\nweight: 2\n",
+				"## Widget v1 example.k8s.io {#widget-v1-example-k8s-io}",
+				"| Group | Version | Kind |\n| --- | --- | --- |\n| `example.k8s.io` | `v1` | `Widget` |",
+				"| Field | Description |\n| --- | --- |\n",
+				// the definition is on another page
+				`| ` + "`spec`" + `<br>*[WidgetSpec]({{< ref "definitions.md#widgetspec-v1-example-k8s-io" >}})* | Spec of the widget. |`,
+			},
+		},
+		{
+			Page: "definitions.md",
+			Expected: []string{
+				"---\ntitle: \"Definitions\"\nweight: 3\n",
+				"## WidgetSpec v1 example.k8s.io {#widgetspec-v1-example-k8s-io}",
+				"WidgetSpec is the specification of a Widget.",
+				`- [Widget \[example/v1\]]({{< ref "workloads.md#widget-v1-example-k8s-io" >}})`,
+				"| `size`<br>*integer* | Size of the widget \\| in units. |",
+				"| `tags`<br>*\\[\\]string* | Tags of the widget.<br>Optional. |",
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Page, func(t *testing.T) {
+			content := read(test.Page)
+			for _, s := range test.Expected {
+				if !strings.Contains(content, s) {
+					t.Errorf("expected %q in:\n%s", s, content)
+				}
+			}
+			for _, s := range test.Missing {
+				if strings.Contains(content, s) {
+					t.Errorf("unexpected %q in:\n%s", s, content)
+				}
+			}
+		})
+	}
+}
+
+func TestNewDocWriterFormats(t *testing.T) {
+	format, multiPage := *Format, *MultiPage
+	defer func() { *Format, *MultiPage = format, multiPage }()
+
+	tests := []struct {
+		Format    string
+		MultiPage bool
+		Error     bool
+	}{
+		{Format: "html"},
+		{Format: "html", MultiPage: true},
+		{Format: "markdown"},
+		{Format: "markdown", MultiPage: true, Error: true},
+		{Format: "pdf", Error: true},
+	}
+	for _, test := range tests {
+		*Format, *MultiPage = test.Format, test.MultiPage
+		_, err := newDocWriter(&api.Config{}, "", "")
+		if test.Error && err == nil {
+			t.Errorf("format %s, multi-page %v: expected an error", test.Format, test.MultiPage)
+		} else if !test.Error && err != nil {
+			t.Errorf("format %s, multi-page %v: unexpected error: %v", test.Format, test.MultiPage, err)
+		}
+	}
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package generators
 
 import (
+	"flag"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -27,6 +28,9 @@ import (
 	"github.com/kubernetes-sigs/reference-docs/gen-apidocs/generators/api"
 )
 
+var Format = flag.String("format", "html", "Output format, either 'html' for a single HTML page or 'markdown' for Hugo pages.")
+var MultiPage = flag.Bool("multi-page", false, "If true, write an HTML page for each resource category and resource instead of a single index.html.")
+
 type Doc struct {
 	Filename string `json:"filename,omitempty"`
 }
@@ -63,7 +67,10 @@ func GenerateFiles() error {
 
 	copyright, title := getCopyrightAndTitle()
 
-	writer := NewHTMLWriter(config, copyright, title)
+	writer, err := newDocWriter(config, copyright, title)
+	if err != nil {
+		return err
+	}
 
 	// Write the main overview page directly to avoid an unnecessary thin wrapper
 	if err := writer.WriteOverview(); err != nil {
@@ -92,6 +99,20 @@ func GenerateFiles() error {
 	return nil
 }
 
+// newDocWriter creates the writer for the output format.
+func newDocWriter(config *api.Config, copyright, title string) (DocWriter, error) {
+	switch *Format {
+	case "html":
+		return NewHTMLWriter(config, copyright, title), nil
+	case "markdown":
+		if *MultiPage {
+			return nil, fmt.Errorf("the 'markdown' format does not support -multi-page, the pages are split by section already")
+		}
+		return NewMarkdownWriter(config, copyright, title), nil
+	}
+	return nil, fmt.Errorf("unsupported output format %q, expecting 'html' or 'markdown'", *Format)
+}
+
 func getCopyrightAndTitle() (string, string) {
 	copyright_tmpl := "<a href=\"https://github.com/kubernetes/kubernetes\">Copyright 2016-%s The Kubernetes Authors.</a>"
 	now := time.Now().Format("2006")
@@ -0,0 +1,89 @@
+package generators
+
+import (
+	"regexp"
+	"strings"
+
+	"k8s.io/gengo/types"
+)
+
+var (
+	// deprecatedPattern matches the Go convention of a paragraph starting
+	// with "Deprecated:", and the variants found in the API comments.
+	deprecatedPattern = regexp.MustCompile(`(?i)^\s*deprecated\s*[:.]|\bthis (field|type) is deprecated\b`)
+
+	// featureGatePattern matches sentences like "Requires the FooBar feature
+	// gate to be enabled" or "requires both the Foo and Bar feature gates".
+	// Only the names of the gates, possibly quoted, are allowed between
+	// "requires" and "feature gate", so that a sentence such as "requires
+	// Kubernetes 1.x and the Foo feature gate" is not taken for a gate name.
+	featureGatePattern = regexp.MustCompile(`\b(?i:requires)\s+(?:(?i:both)\s+)?(?:(?i:the)\s+)?(` +
+		featureGateName + `(?:(?:\s*,\s*(?:(?i:and)\s+)?|\s+(?i:and)\s+)(?:(?i:the)\s+)?` + featureGateName + `)*)` +
+		`\s+(?i:feature)[ -]?(?i:gates?)\b`)
+
+	// featureGateNamePattern matches a feature gate name, possibly quoted.
+	featureGateNamePattern = regexp.MustCompile(featureGateName)
+)
+
+// featureGateName is a feature gate name, possibly quoted, e.g. `FooBar`.
+const featureGateName = "[\"'`]?([A-Z][A-Za-z0-9]+)[\"'`]?"
+
+// isDeprecated tests if the comment lines mark a type or a field as
+// deprecated, or if a '+deprecated' marker is found.
+func isDeprecated(comments []string) bool {
+	if _, ok := types.ExtractCommentTags("+", comments)["deprecated"]; ok {
+		return true
+	}
+	for _, line := range comments {
+		if deprecatedPattern.MatchString(line) {
+			return true
+		}
+	}
+	return false
+}
+
+// featureGates returns the feature gates required by a type or a field, from
+// the '+featureGate=' markers and the comments, in the order they are found.
+func featureGates(comments []string) []string {
+	var gates []string
+	add := func(name string) {
+		if name != "" && !containsString(gates, name) {
+			gates = append(gates, name)
+		}
+	}
+
+	for _, v := range types.ExtractCommentTags("+", comments)["featureGate"] {
+		for _, name := range strings.Split(v, ",") {
+			add(strings.TrimSpace(name))
+		}
+	}
+
+	// sentences can span several comment lines
+	text := strings.Join(comments, " ")
+	for _, m := range featureGatePattern.FindAllStringSubmatch(text, -1) {
+		for _, name := range featureGateNamePattern.FindAllStringSubmatch(m[1], -1) {
+			add(name[1])
+		}
+	}
+	return gates
+}
+
+// Deprecated tests if the type is deprecated.
+func (t *apiType) Deprecated() bool {
+	return isDeprecated(t.CommentLines)
+}
+
+// FeatureGate returns the feature gates the type requires.
+func (t *apiType) FeatureGate() []string {
+	return featureGates(t.CommentLines)
+}
+
+// Deprecated tests if the field is deprecated.
+func (m *apiMember) Deprecated() bool {
+	return isDeprecated(m.CommentLines)
+}
+
+// FeatureGate returns the feature gates the field requires.
+func (m *apiMember) FeatureGate() []string {
+	return featureGates(m.CommentLines)
+}
@@ -0,0 +1,64 @@
+package generators
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestIsDeprecated(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Comments []string
+		Expected bool
+	}{
+		{"no comment", nil, false},
+		{"plain comment", []string{"Port is the port to listen on."}, false},
+		{"go convention", []string{"Port is the port.", "", "Deprecated: use ports instead."}, true},
+		{"lower case with a period", []string{"deprecated. This field is ignored."}, true},
+		{"this field is deprecated", []string{"Address to bind. This field is deprecated", "and will be removed."}, true},
+		{"marker", []string{"+deprecated"}, true},
+		{"mention of a deprecated field", []string{"It replaces the deprecated address field."}, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			if result := isDeprecated(test.Comments); result != test.Expected {
+				t.Errorf("Expected %v, got %v", test.Expected, result)
+			}
+		})
+	}
+}
+
+func TestFeatureGates(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Comments []string
+		Expected []string
+	}{
+		{"no feature gate", []string{"Port is the port to listen on."}, nil},
+		{"marker", []string{"+featureGate=FooBar"}, []string{"FooBar"}},
+		{"marker with a list", []string{"+featureGate=Foo, Bar"}, []string{"Foo", "Bar"}},
+		{"sentence", []string{"Requires the FooBar feature gate to be enabled."}, []string{"FooBar"}},
+		{"quoted", []string{"This field requires the `FooBar` feature-gate."}, []string{"FooBar"}},
+		{"both", []string{"Requires both the Foo and Bar feature gates."}, []string{"Foo", "Bar"}},
+		{"across lines", []string{"This field requires the", "FooBar feature gate."}, []string{"FooBar"}},
+		{"list", []string{"Requires the Foo, Bar, and Baz feature gates."}, []string{"Foo", "Bar", "Baz"}},
+		{"not a name", []string{"This field requires Kubernetes v1 and the Foo feature gate."}, nil},
+		{"lower case words", []string{"Setting it requires the feature gate to be enabled."}, nil},
+		{"sentence in between", []string{"Requires a restart. The Foo feature gate is deprecated."}, nil},
+		{"no feature gate after the name", []string{"Requires the FooBar field to be set."}, nil},
+		{
+			Name:     "marker and sentence without duplicates",
+			Comments: []string{"Requires the Foo feature gate.", "+featureGate=Foo"},
+			Expected: []string{"Foo"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			if result := featureGates(test.Comments); !reflect.DeepEqual(result, test.Expected) {
+				t.Errorf("Expected %v, got %v", test.Expected, result)
+			}
+		})
+	}
+}
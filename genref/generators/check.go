@@ -0,0 +1,54 @@
+package generators
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"regexp"
+
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// gitCommitPattern matches the git commit of the generator in the HTML pages.
+var gitCommitPattern = regexp.MustCompile(`( on git commit <code>)[0-9a-f]+(</code>)`)
+
+// normalizeGitCommit removes the git commit from the content, which changes
+// with every commit without the pages being out of date.
+func normalizeGitCommit(content []byte) []byte {
+	return gitCommitPattern.ReplaceAll(content, []byte("${1}${2}"))
+}
+
+// Diff compares the content of a result with the existing file at its path.
+// It returns a unified diff from the file to the content, or an empty string
+// if the file is up to date. A missing file is compared as an empty one. The
+// git commits in the HTML pages are not compared.
+func (r Result) Diff() (string, error) {
+	fromFile := r.Path
+	current, err := os.ReadFile(r.Path)
+	if errors.Is(err, fs.ErrNotExist) {
+		fromFile = "/dev/null"
+	} else if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", r.Path, err)
+	}
+	if bytes.Equal(normalizeGitCommit(current), normalizeGitCommit(r.Content)) {
+		return "", nil
+	}
+
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(string(current)),
+		B:        difflib.SplitLines(string(r.Content)),
+		FromFile: fromFile,
+		ToFile:   r.Path,
+		Context:  3,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to compare %s: %w", r.Path, err)
+	}
+	if diff == "" {
+		// the lines are the same except for the newline at the end
+		diff = fmt.Sprintf("--- %s\n+++ %s\n\\ Newline at end of file differs\n", fromFile, r.Path)
+	}
+	return diff, nil
+}
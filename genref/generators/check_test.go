@@ -0,0 +1,44 @@
+package generators
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestResultDiff(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "page.html")
+	page := "<p><em>Generated with <code>genref</code> on git commit <code>%s</code></em></p>\n"
+	if err := os.WriteFile(path, []byte(fmt.Sprintf(page, "1a2b3c4")), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		Name    string
+		Content string
+		Stale   bool
+	}{
+		{"same commit", fmt.Sprintf(page, "1a2b3c4"), false},
+		{"other commit", fmt.Sprintf(page, "5d6e7f8"), false},
+		{"no commit", "<p><em>Generated with <code>genref</code></em></p>\n", true},
+		{"changed content", fmt.Sprintf(page, "1a2b3c4") + "<p>new</p>\n", true},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			diff, err := Result{Path: path, Content: []byte(test.Content)}.Diff()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if stale := diff != ""; stale != test.Stale {
+				t.Errorf("Expected stale to be %v, got diff %q", test.Stale, diff)
+			}
+		})
+	}
+
+	diff, err := Result{Path: filepath.Join(dir, "missing.html"), Content: []byte("a\n")}.Diff()
+	if err != nil || diff == "" {
+		t.Errorf("Expected a diff for a missing file, got %q (%v)", diff, err)
+	}
+}
@@ -0,0 +1,160 @@
+package generators
+
+import (
+	"fmt"
+	"os"
+
+	"sigs.k8s.io/yaml"
+)
+
+// Config is the generator configuration, usually loaded from a YAML file.
+type Config struct {
+	// HiddenMemberFields hides fields with specified names on all types.
+	HiddenMemberFields []string `json:"hiddenMemberFields"`
+
+	// HideTypePatterns hides types matching the specified patterns from the
+	// output.
+	HideTypePatterns []string `json:"hideTypePatterns"`
+
+	// VisibilityRules hides or shows types and fields selectively. The rules
+	// take precedence over HiddenMemberFields and HideTypePatterns.
+	VisibilityRules []VisibilityRule `json:"visibilityRules,omitempty"`
+
+	// ExternalPackages lists recognized external package references and how to
+	// link to them.
+	ExternalPackages []ExternalPackage `json:"externalPackages"`
+
+	// KubernetesVersion is the Kubernetes release the docs are generated for,
+	// e.g. 'v1.35'. It is available to the 'target' templates of the external
+	// packages.
+	KubernetesVersion string `json:"kubernetesVersion,omitempty"`
+
+	// Vars are the variables available to the 'target' templates of the
+	// external packages as '.Vars.<name>'.
+	Vars map[string]string `json:"vars,omitempty"`
+
+	// Releases are the source roots of the Kubernetes releases for the
+	// history of the types and fields.
+	Releases []Release `json:"releases,omitempty"`
+
+	// StripPrefix is a list of type name prefixes that will be stripped
+	StripPrefix []string `json:"stripPrefix"`
+
+	// MarkdownDisabled controls markdown rendering for comment lines.
+	MarkdownDisabled bool `json:"markdownDisabled"`
+
+	// APIs to process
+	Definitions []APIDefinition `json:"apis"`
+}
+
+// ExternalPackage specifies how to link to the types documented elsewhere.
+type ExternalPackage struct {
+	// Match is a reqular expression for matching type names which are defined
+	// and documented externally.
+	Match string `json:"match"`
+
+	// Target provides a text template string for building the link to the
+	// external documentation for a type. The template data has the fields
+	// TypeIdentifier, PackagePath, PackageSegments, KubernetesVersion,
+	// KubernetesMinor and Vars.
+	Target string `json:"target"`
+}
+
+// Release is the source root of a Kubernetes release.
+type Release struct {
+	// Version is the release version, e.g. 'v1.30'.
+	Version string `json:"version"`
+
+	// Path is a kubernetes/kubernetes checkout of the release, or a directory
+	// with the packages at <path>/<import path>.
+	Path string `json:"path"`
+}
+
+// VisibilityRule hides or shows the types or fields matching its selectors.
+// The selectors that are not specified match anything. A rule with a field
+// selector applies to the fields of the selected types, otherwise it applies
+// to the types themselves. When several rules match, the last one wins.
+type VisibilityRule struct {
+	// API is the name of the API definition the rule is restricted to.
+	API string `json:"api,omitempty"`
+
+	// FromIncludes restricts the rule to the types from the packages listed
+	// in 'includes' of the API definition.
+	FromIncludes bool `json:"fromIncludes,omitempty"`
+
+	// Package is a regular expression matching the whole Go package path of
+	// the types.
+	Package string `json:"package,omitempty"`
+
+	// Type is a regular expression matching the whole type name.
+	Type string `json:"type,omitempty"`
+
+	// Field is a regular expression matching the whole Go name or serialized
+	// name of the fields.
+	Field string `json:"field,omitempty"`
+
+	// Action is either 'include' or 'exclude'.
+	Action string `json:"action"`
+}
+
+// APIDefinition specifies the API type definitions for which reference
+// documentations are to be generated. These definitions are provided and
+// customized in the configuration YAML as well.
+type APIDefinition struct {
+	// Name is the key string that represents a specific package
+	Name string `json:"name"`
+
+	// Title is the string that will appear as the title of the generated page
+	Title string `json:"title"`
+
+	// Package is the import path for the API package where a type is defined.
+	Package string `json:"package"`
+
+	// Path is the path for an API type/resource definition. Each package has
+	// a different convention of defining its API types.
+	Path string `json:"path"`
+
+	// Skip is a boolean flag indicating whether the package currently has
+	// some problems in generating reference docs. We tag a package as
+	// skipped if the current generator doesn't work on it.
+	Skip bool `json:"skip,omitempty"`
+
+	// Includes is list of packages that are designed for shared type
+	// definitions.
+	Includes []string `json:"includes"`
+
+	// MainPackage is an override for API definitions that involves more
+	// than one package.
+	MainPackage string `json:"mainPackage"`
+
+	// Resource types manually specified
+	Resources []string `json:"resources"`
+
+	// LocalPath is an optional local checkout of the Go module providing the
+	// package. The module is replaced by the checkout when loading packages.
+	LocalPath string `json:"localPath,omitempty"`
+
+	// DefaultsPackages are the import paths of the packages declaring the
+	// defaulting functions of the API types, when they are not declared in
+	// the API packages, e.g. 'k8s.io/kubernetes/pkg/kubelet/apis/config/v1beta1'.
+	DefaultsPackages []string `json:"defaultsPackages,omitempty"`
+
+	// DefaultsLocalPath is an optional local checkout of the Go module
+	// providing the defaults packages, for a module that cannot be required
+	// in go.mod such as k8s.io/kubernetes.
+	DefaultsLocalPath string `json:"defaultsLocalPath,omitempty"`
+}
+
+// LoadConfig reads the generator configuration from a YAML file.
+func LoadConfig(path string) (*Config, error) {
+	f, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open config file: %w", err)
+	}
+
+	var cfg Config
+	if err = yaml.UnmarshalStrict(f, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse config file: %w", err)
+	}
+	return &cfg, nil
+}
@@ -0,0 +1,201 @@
+package generators
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"k8s.io/gengo/types"
+	"sigs.k8s.io/yaml"
+)
+
+// dataSchemaVersion is bumped whenever a field in the serialized output is
+// renamed or removed. Adding new fields does not require a bump.
+const dataSchemaVersion = "v1"
+
+// apiDocument is the machine-readable form of the generated reference, used
+// by the 'json' and 'yaml' output formats.
+type apiDocument struct {
+	SchemaVersion string       `json:"schemaVersion"`
+	Packages      []packageDoc `json:"packages"`
+}
+
+// packageDoc is the serialized form of an apiPackage.
+type packageDoc struct {
+	Name    string    `json:"name"`
+	Group   string    `json:"group"`
+	Version string    `json:"version"`
+	Title   string    `json:"title,omitempty"`
+	IsMain  bool      `json:"isMain"`
+	Comment string    `json:"comment,omitempty"`
+	Types   []typeDoc `json:"types"`
+}
+
+// typeDoc is the serialized form of an apiType.
+type typeDoc struct {
+	Name        string       `json:"name"`
+	Package     string       `json:"package"`
+	Kind        string       `json:"kind"`
+	DisplayName string       `json:"displayName"`
+	Anchor      string       `json:"anchor"`
+	IsExported  bool         `json:"isExported"`
+	Referenced  bool         `json:"referenced"`
+	Underlying  *typeRefDoc  `json:"underlying,omitempty"`
+	Enum        []enumDoc    `json:"enum,omitempty"`
+	Comment     string       `json:"comment,omitempty"`
+	Deprecated  bool         `json:"deprecated,omitempty"`
+	FeatureGate []string     `json:"featureGate,omitempty"`
+	AddedIn     string       `json:"addedIn,omitempty"`
+	RemovedIn   string       `json:"removedIn,omitempty"`
+	References  []typeRefDoc `json:"references,omitempty"`
+	Members     []memberDoc  `json:"members,omitempty"`
+	Unions      []unionDoc   `json:"unions,omitempty"`
+}
+
+// unionDoc is the serialized form of an apiUnion.
+type unionDoc struct {
+	Members       []string `json:"members"`
+	Discriminator string   `json:"discriminator,omitempty"`
+	Exactly       bool     `json:"exactly"`
+}
+
+// typeRefDoc is a reference to another type, e.g. the type of a member.
+type typeRefDoc struct {
+	ID          string `json:"id"`
+	Kind        string `json:"kind"`
+	DisplayName string `json:"displayName"`
+	Link        string `json:"link,omitempty"`
+}
+
+// enumDoc is the serialized form of an enumValue.
+type enumDoc struct {
+	Name    string `json:"name"`
+	Value   string `json:"value"`
+	Comment string `json:"comment,omitempty"`
+}
+
+// memberDoc is the serialized form of an apiMember.
+type memberDoc struct {
+	Name        string            `json:"name"`
+	FieldName   string            `json:"fieldName"`
+	Type        typeRefDoc        `json:"type"`
+	IsOptional  bool              `json:"isOptional"`
+	IsInline    bool              `json:"isInline"`
+	Comment     string            `json:"comment,omitempty"`
+	Deprecated  bool              `json:"deprecated,omitempty"`
+	FeatureGate []string          `json:"featureGate,omitempty"`
+	AddedIn     string            `json:"addedIn,omitempty"`
+	RemovedIn   string            `json:"removedIn,omitempty"`
+	Constraints *fieldConstraints `json:"constraints,omitempty"`
+}
+
+// newAPIDocument builds the serializable document for the given packages.
+// Only the visible types and members are included, the same as what the
+// templates render.
+func newAPIDocument(pkgs []*apiPackage) *apiDocument {
+	doc := &apiDocument{
+		SchemaVersion: dataSchemaVersion,
+		Packages:      make([]packageDoc, 0, len(pkgs)),
+	}
+	for _, p := range pkgs {
+		pd := packageDoc{
+			Name:    p.DisplayName(),
+			Group:   p.apiGroup,
+			Version: p.apiVersion,
+			Title:   p.Title,
+			IsMain:  p.IsMain,
+			Comment: string(p.GetComment()),
+			Types:   []typeDoc{},
+		}
+		for _, t := range p.VisibleTypes() {
+			pd.Types = append(pd.Types, newTypeDoc(t))
+		}
+		doc.Packages = append(doc.Packages, pd)
+	}
+	return doc
+}
+
+func newTypeDoc(t *apiType) typeDoc {
+	td := typeDoc{
+		Name:        t.Name.Name,
+		Package:     t.Name.Package,
+		Kind:        string(t.Kind),
+		DisplayName: t.DisplayName(),
+		Anchor:      t.Anchor(),
+		IsExported:  t.IsExported(),
+		Referenced:  t.Referenced(),
+		Comment:     string(t.GetComment()),
+		Deprecated:  t.Deprecated(),
+		FeatureGate: t.FeatureGate(),
+		AddedIn:     t.AddedIn(),
+		RemovedIn:   t.RemovedIn(),
+	}
+	if t.Kind == types.Alias && t.Underlying != nil {
+		u := newTypeRefDoc(t.wrap(t.Underlying))
+		td.Underlying = &u
+	}
+	for _, v := range t.AllowedValues() {
+		td.Enum = append(td.Enum, enumDoc{
+			Name:    v.Name,
+			Value:   v.Value,
+			Comment: string(v.GetComment()),
+		})
+	}
+	for _, ref := range t.References() {
+		td.References = append(td.References, newTypeRefDoc(ref))
+	}
+	members := t.GetMembers()
+	for _, u := range t.unions(members) {
+		td.Unions = append(td.Unions, unionDoc{
+			Members:       u.FieldNames(),
+			Discriminator: u.Discriminator,
+			Exactly:       u.Exactly,
+		})
+	}
+	for _, m := range members {
+		if m.Hidden() {
+			continue
+		}
+		td.Members = append(td.Members, memberDoc{
+			Name:        m.Name,
+			FieldName:   m.FieldName(),
+			Type:        newTypeRefDoc(m.GetType()),
+			IsOptional:  m.IsOptional(),
+			IsInline:    m.IsInline(),
+			Comment:     string(m.GetComment()),
+			Deprecated:  m.Deprecated(),
+			FeatureGate: m.FeatureGate(),
+			AddedIn:     m.AddedIn(),
+			RemovedIn:   m.RemovedIn(),
+			Constraints: m.Constraints,
+		})
+	}
+	return td
+}
+
+func newTypeRefDoc(t *apiType) typeRefDoc {
+	return typeRefDoc{
+		ID:          t.String(),
+		Kind:        string(t.Kind),
+		DisplayName: t.DisplayName(),
+		Link:        t.Link(),
+	}
+}
+
+// renderData writes a document in a serialized format, either 'json' or
+// 'yaml'.
+func renderData(w io.Writer, doc interface{}, format string) error {
+	b, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal document: %w", err)
+	}
+	if format == "yaml" {
+		if b, err = yaml.JSONToYAML(b); err != nil {
+			return fmt.Errorf("failed to convert to YAML: %w", err)
+		}
+	} else {
+		b = append(b, '\n')
+	}
+	_, err = w.Write(b)
+	return err
+}
@@ -0,0 +1,751 @@
+package generators
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/constant"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/mod/modfile"
+	"k8s.io/gengo/types"
+	"k8s.io/klog/v2"
+)
+
+const defaulterPrefix = "SetDefaults_"
+
+// defaultValue is a statically resolved default value.
+type defaultValue struct {
+	// val is set for scalar values
+	val constant.Value
+	// duration is set if val is a number of nanoseconds
+	duration bool
+	// text is set for composite values, in JSON format
+	text string
+}
+
+// String returns the display format of a default value. Strings are shown
+// without quotes, the same as the values from '+default=' markers.
+func (v defaultValue) String() string {
+	if v.val == nil {
+		return v.text
+	}
+	if v.duration {
+		if d, ok := constant.Int64Val(v.val); ok {
+			return time.Duration(d).String()
+		}
+	}
+	if v.val.Kind() == constant.String {
+		return constant.StringVal(v.val)
+	}
+	return v.val.String()
+}
+
+// jsonValue returns the value as an element of a JSON list or object.
+func (v defaultValue) jsonValue() interface{} {
+	if v.val == nil {
+		return json.RawMessage(v.text)
+	}
+	if v.duration || v.val.Kind() == constant.String {
+		return v.String()
+	}
+	return json.RawMessage(v.val.String())
+}
+
+// fileScope is the context for resolving identifiers in a Go source file.
+type fileScope struct {
+	pkgPath string
+	// imports maps the local package names to the import paths
+	imports map[string]string
+}
+
+// valueDecl is a constant or variable declaration found in a Go source file.
+type valueDecl struct {
+	expr  ast.Expr
+	scope *fileScope
+}
+
+// defaultsExtractor finds 'SetDefaults_<Type>' functions in the API packages
+// and resolves the values assigned to the fields.
+type defaultsExtractor struct {
+	fset *token.FileSet
+	// values caches the constants and variables found in each package, by
+	// import path
+	values map[string]map[string]*valueDecl
+	// dirs is the source directory for known packages
+	dirs map[string]string
+	// modules is the source directory of the modules with a local checkout,
+	// by module path
+	modules map[string]string
+	// apiTypes are the types known to gengo, by name (PkgPath.Name)
+	apiTypes map[string]*types.Type
+	// defaults is where the resolved values are recorded
+	defaults map[string]string
+	// loader finds the directories of the packages unknown to gengo
+	loader *moduleLoader
+	// missing is the last constant or variable that is not found, for
+	// diagnostics
+	missing string
+}
+
+// newDefaultsExtractor creates an extractor recording the values in the
+// defaults map.
+func newDefaultsExtractor(defaults map[string]string, loader *moduleLoader) *defaultsExtractor {
+	return &defaultsExtractor{
+		defaults: defaults,
+		loader:   loader,
+		fset:     token.NewFileSet(),
+		values:   map[string]map[string]*valueDecl{},
+		dirs:     map[string]string{},
+		modules:  map[string]string{},
+		apiTypes: map[string]*types.Type{},
+	}
+}
+
+// extractDefaults resolves the default values set in the defaulting functions
+// of the given packages, and of the defaults packages of the API definition,
+// and records them in the defaults map, keyed by PackagePath.Type.Field.
+func extractDefaults(pkgs []*apiPackage, item APIDefinition, defaults map[string]string, loader *moduleLoader) {
+	e := newDefaultsExtractor(defaults, loader)
+	var paths []string
+	for _, p := range pkgs {
+		for _, gopkg := range p.GoPackages {
+			e.dirs[gopkg.Path] = gopkg.SourcePath
+			for _, t := range gopkg.Types {
+				e.apiTypes[t.Name.String()] = t
+			}
+			paths = append(paths, gopkg.Path)
+		}
+	}
+	if item.DefaultsLocalPath != "" {
+		if err := e.addModule(item.DefaultsLocalPath); err != nil {
+			klog.Warningf("Ignoring defaultsLocalPath for API %s: %v", item.Name, err)
+		}
+	}
+	paths = append(paths, item.DefaultsPackages...)
+
+	unresolved := 0
+	for _, path := range paths {
+		files, err := e.parsePackage(path)
+		if err != nil {
+			if containsString(item.DefaultsPackages, path) && item.DefaultsLocalPath == "" {
+				klog.Warningf("Cannot parse defaults package %s of API %s, set 'defaultsLocalPath' to a checkout of its module: %v",
+					path, item.Name, err)
+				continue
+			}
+			klog.Warningf("Cannot parse package %s for defaulting functions: %v", path, err)
+			continue
+		}
+		for _, f := range files {
+			unresolved += e.extractFile(path, f)
+		}
+	}
+	if unresolved > 0 {
+		klog.Warningf("%d default value assignment(s) cannot be resolved", unresolved)
+	}
+}
+
+// addModule registers a local checkout of a module, for the packages that
+// cannot be resolved through go.mod.
+func (e *defaultsExtractor) addModule(dir string) error {
+	data, err := os.ReadFile(filepath.Join(dir, "go.mod"))
+	if err != nil {
+		return err
+	}
+	mod := modfile.ModulePath(data)
+	if mod == "" {
+		return fmt.Errorf("no module path found in %s", filepath.Join(dir, "go.mod"))
+	}
+	e.modules[mod] = dir
+	return nil
+}
+
+// extractFile records the default values assigned in the defaulting
+// functions of a Go source file. It returns the number of assignments that
+// cannot be resolved.
+func (e *defaultsExtractor) extractFile(pkgPath string, f *ast.File) int {
+	scope := e.fileScope(pkgPath, f)
+	unresolved := 0
+	for _, decl := range f.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Recv != nil || !strings.HasPrefix(fn.Name.Name, defaulterPrefix) {
+			continue
+		}
+		unresolved += e.extractFunc(fn, scope)
+	}
+	return unresolved
+}
+
+// paramType returns the API type of the parameter of a defaulting function,
+// which is a pointer to a type of the same package, or to a type of an
+// imported API package when the function is in a separate defaults package,
+// e.g. '*kubeletconfigv1beta1.KubeletConfiguration'.
+func (e *defaultsExtractor) paramType(expr ast.Expr, scope *fileScope) *types.Type {
+	star, ok := expr.(*ast.StarExpr)
+	if !ok {
+		return nil
+	}
+	switch x := star.X.(type) {
+	case *ast.Ident:
+		return e.apiTypes[scope.pkgPath+"."+x.Name]
+	case *ast.SelectorExpr:
+		pkg, ok := x.X.(*ast.Ident)
+		if !ok {
+			return nil
+		}
+		path, ok := scope.imports[pkg.Name]
+		if !ok {
+			return nil
+		}
+		return e.apiTypes[path+"."+x.Sel.Name]
+	}
+	return nil
+}
+
+// extractFunc records the default values assigned in a defaulting function.
+// It returns the number of assignments that cannot be resolved.
+func (e *defaultsExtractor) extractFunc(fn *ast.FuncDecl, scope *fileScope) int {
+	params := fn.Type.Params.List
+	if len(params) != 1 || len(params[0].Names) != 1 {
+		return 0
+	}
+	objType := e.paramType(params[0].Type, scope)
+	if objType == nil {
+		return 0
+	}
+	objName := params[0].Names[0].Name
+	// values computed at runtime, from the object or from local variables,
+	// are not defaults that can be documented
+	runtimeNames := localNames(fn.Body)
+	runtimeNames[objName] = true
+
+	unresolved := 0
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		if _, ok := n.(*ast.FuncLit); ok {
+			return false
+		}
+		assign, ok := n.(*ast.AssignStmt)
+		if !ok || assign.Tok != token.ASSIGN || len(assign.Lhs) != len(assign.Rhs) {
+			return true
+		}
+		for i, lhs := range assign.Lhs {
+			path := selectorPath(lhs, objName)
+			if len(path) == 0 {
+				continue
+			}
+			owner, member := findMemberPath(objType, path)
+			if member == nil {
+				continue
+			}
+			if isEmptyComposite(assign.Rhs[i]) {
+				// initialization for defaulting the nested fields
+				continue
+			}
+			key := owner.Name.String() + "." + member.Name
+			if referencesAny(assign.Rhs[i], runtimeNames) {
+				klog.V(2).Infof("Skipping default value for %s in %s computed at runtime: %s",
+					key, fn.Name.Name, e.exprString(assign.Rhs[i]))
+				continue
+			}
+			e.missing = ""
+			v, ok := e.resolve(assign.Rhs[i], scope)
+			if !ok {
+				reason := ""
+				if e.missing != "" {
+					reason = fmt.Sprintf(" (%s is not declared in the sources)", e.missing)
+				}
+				klog.Warningf("Cannot resolve default value for %s in %s: %s%s",
+					key, fn.Name.Name, e.exprString(assign.Rhs[i]), reason)
+				unresolved++
+				continue
+			}
+			if _, found := e.defaults[key]; !found {
+				e.defaults[key] = v.String()
+			}
+		}
+		return true
+	})
+	return unresolved
+}
+
+// localNames returns the names of the variables declared in a function body.
+func localNames(body *ast.BlockStmt) map[string]bool {
+	names := map[string]bool{}
+	add := func(exprs ...ast.Expr) {
+		for _, x := range exprs {
+			if ident, ok := x.(*ast.Ident); ok {
+				names[ident.Name] = true
+			}
+		}
+	}
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch x := n.(type) {
+		case *ast.AssignStmt:
+			if x.Tok == token.DEFINE {
+				add(x.Lhs...)
+			}
+		case *ast.RangeStmt:
+			if x.Tok == token.DEFINE {
+				add(x.Key, x.Value)
+			}
+		case *ast.ValueSpec:
+			for _, ident := range x.Names {
+				names[ident.Name] = true
+			}
+		}
+		return true
+	})
+	return names
+}
+
+// referencesAny tests if an expression refers to any of the named variables.
+// The field names of selectors and composite literals are not references.
+func referencesAny(expr ast.Expr, names map[string]bool) bool {
+	found := false
+	ast.Inspect(expr, func(n ast.Node) bool {
+		if found {
+			return false
+		}
+		switch x := n.(type) {
+		case *ast.SelectorExpr:
+			found = referencesAny(x.X, names)
+			return false
+		case *ast.KeyValueExpr:
+			found = referencesAny(x.Value, names)
+			return false
+		case *ast.Ident:
+			found = names[x.Name]
+		}
+		return true
+	})
+	return found
+}
+
+// isEmptyComposite tests if an expression is an empty composite literal such
+// as '&Foo{}', optionally with its address taken.
+func isEmptyComposite(expr ast.Expr) bool {
+	if u, ok := expr.(*ast.UnaryExpr); ok && u.Op == token.AND {
+		expr = u.X
+	}
+	lit, ok := expr.(*ast.CompositeLit)
+	return ok && len(lit.Elts) == 0
+}
+
+// selectorPath returns the field names in a selector chain rooted at the
+// specified identifier, e.g. [Etcd Local DataDir] for 'obj.Etcd.Local.DataDir'.
+func selectorPath(expr ast.Expr, root string) []string {
+	var path []string
+	for {
+		switch x := expr.(type) {
+		case *ast.SelectorExpr:
+			path = append([]string{x.Sel.Name}, path...)
+			expr = x.X
+		case *ast.ParenExpr:
+			expr = x.X
+		case *ast.StarExpr:
+			expr = x.X
+		case *ast.Ident:
+			if x.Name == root {
+				return path
+			}
+			return nil
+		default:
+			return nil
+		}
+	}
+}
+
+// findMemberPath walks the members of a struct type following the given
+// field names. It returns the struct type owning the last field and the
+// member itself, or a nil member if the path cannot be followed.
+func findMemberPath(t *types.Type, path []string) (*types.Type, *types.Member) {
+	for i, name := range path {
+		owner, m := findMember(t, name)
+		if m == nil {
+			return nil, nil
+		}
+		if i == len(path)-1 {
+			return owner, m
+		}
+		t = m.Type
+		for t.Kind == types.Pointer {
+			t = t.Elem
+		}
+	}
+	return nil, nil
+}
+
+// findMember looks up a member by its Go name, including the members promoted
+// from embedded structs.
+func findMember(t *types.Type, name string) (*types.Type, *types.Member) {
+	for i := range t.Members {
+		if t.Members[i].Name == name {
+			return t, &t.Members[i]
+		}
+	}
+	for _, m := range t.Members {
+		if !m.Embedded {
+			continue
+		}
+		mt := m.Type
+		for mt.Kind == types.Pointer {
+			mt = mt.Elem
+		}
+		if owner, found := findMember(mt, name); found != nil {
+			return owner, found
+		}
+	}
+	return nil, nil
+}
+
+// resolve statically evaluates an expression to a default value.
+func (e *defaultsExtractor) resolve(expr ast.Expr, scope *fileScope) (defaultValue, bool) {
+	switch x := expr.(type) {
+	case *ast.BasicLit:
+		v := constant.MakeFromLiteral(x.Value, x.Kind, 0)
+		return defaultValue{val: v}, v.Kind() != constant.Unknown
+	case *ast.ParenExpr:
+		return e.resolve(x.X, scope)
+	case *ast.UnaryExpr:
+		if x.Op == token.AND {
+			return e.resolve(x.X, scope)
+		}
+		v, ok := e.resolve(x.X, scope)
+		if !ok || v.val == nil {
+			return defaultValue{}, false
+		}
+		v.val, ok = unaryOp(x.Op, v.val)
+		return v, ok
+	case *ast.BinaryExpr:
+		l, ok1 := e.resolve(x.X, scope)
+		r, ok2 := e.resolve(x.Y, scope)
+		if !ok1 || !ok2 || l.val == nil || r.val == nil {
+			return defaultValue{}, false
+		}
+		v := defaultValue{duration: l.duration || r.duration}
+		var ok bool
+		v.val, ok = binaryOp(l.val, x.Op, r.val)
+		return v, ok
+	case *ast.Ident:
+		switch x.Name {
+		case "true", "false":
+			return defaultValue{val: constant.MakeBool(x.Name == "true")}, true
+		}
+		return e.resolveValue(scope.pkgPath, x.Name)
+	case *ast.SelectorExpr:
+		pkg, ok := x.X.(*ast.Ident)
+		if !ok {
+			return defaultValue{}, false
+		}
+		path, ok := scope.imports[pkg.Name]
+		if !ok {
+			return defaultValue{}, false
+		}
+		if path == "time" {
+			if d, ok := timeUnits[x.Sel.Name]; ok {
+				return defaultValue{val: constant.MakeInt64(int64(d)), duration: true}, true
+			}
+		}
+		return e.resolveValue(path, x.Sel.Name)
+	case *ast.CallExpr:
+		if len(x.Args) != 1 || !e.isTransparentCall(x.Fun, scope) {
+			return defaultValue{}, false
+		}
+		v, ok := e.resolve(x.Args[0], scope)
+		if ok && v.val != nil && e.isDurationType(x.Fun, scope) {
+			v.duration = true
+		}
+		return v, ok
+	case *ast.CompositeLit:
+		return e.resolveComposite(x, scope)
+	}
+	return defaultValue{}, false
+}
+
+// unaryOp evaluates a unary operation. It returns false instead of panicking
+// for an operand of the wrong kind.
+func unaryOp(op token.Token, x constant.Value) (v constant.Value, ok bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			v, ok = nil, false
+		}
+	}()
+	v = constant.UnaryOp(op, x, 0)
+	return v, v.Kind() != constant.Unknown
+}
+
+// binaryOp evaluates a binary operation the way Go does, e.g. the division of
+// two integers is truncated. It returns false instead of panicking for the
+// operands that cannot be combined, such as a division by zero.
+func binaryOp(l constant.Value, op token.Token, r constant.Value) (v constant.Value, ok bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			v, ok = nil, false
+		}
+	}()
+
+	numeric := isNumeric(l) && isNumeric(r)
+	if !numeric && l.Kind() != r.Kind() {
+		return nil, false
+	}
+	switch op {
+	case token.EQL, token.NEQ, token.LSS, token.LEQ, token.GTR, token.GEQ:
+		return constant.MakeBool(constant.Compare(l, op, r)), true
+	case token.SHL, token.SHR:
+		s, exact := constant.Uint64Val(r)
+		if l.Kind() != constant.Int || r.Kind() != constant.Int || !exact {
+			return nil, false
+		}
+		return constant.Shift(l, op, uint(s)), true
+	case token.QUO, token.REM:
+		if !numeric || constant.Sign(r) == 0 {
+			return nil, false
+		}
+		if op == token.QUO && l.Kind() == constant.Int && r.Kind() == constant.Int {
+			op = token.QUO_ASSIGN
+		}
+	}
+	v = constant.BinaryOp(l, op, r)
+	return v, v.Kind() != constant.Unknown
+}
+
+// isNumeric tests if a value is an integer, a float or a complex number.
+func isNumeric(v constant.Value) bool {
+	switch v.Kind() {
+	case constant.Int, constant.Float, constant.Complex:
+		return true
+	}
+	return false
+}
+
+// timeUnits are the duration constants from the 'time' package.
+var timeUnits = map[string]time.Duration{
+	"Nanosecond":  time.Nanosecond,
+	"Microsecond": time.Microsecond,
+	"Millisecond": time.Millisecond,
+	"Second":      time.Second,
+	"Minute":      time.Minute,
+	"Hour":        time.Hour,
+}
+
+// pointerPackages are the packages whose single argument functions return a
+// pointer to the argument.
+var pointerPackages = []string{
+	"k8s.io/utils/ptr",
+	"k8s.io/utils/pointer",
+}
+
+// isTransparentCall tests if a call with a single argument evaluates to the
+// argument itself, i.e. a type conversion or a pointer helper.
+func (e *defaultsExtractor) isTransparentCall(fun ast.Expr, scope *fileScope) bool {
+	switch f := fun.(type) {
+	case *ast.IndexExpr:
+		// generic instantiation such as ptr.To[int32]
+		return e.isTransparentCall(f.X, scope)
+	case *ast.Ident:
+		switch f.Name {
+		case "string", "bool", "int", "int8", "int16", "int32", "int64",
+			"uint", "uint8", "uint16", "uint32", "uint64", "float32", "float64":
+			return true
+		}
+		return e.apiTypes[scope.pkgPath+"."+f.Name] != nil
+	case *ast.SelectorExpr:
+		pkg, ok := f.X.(*ast.Ident)
+		if !ok {
+			return false
+		}
+		path := scope.imports[pkg.Name]
+		if containsString(pointerPackages, path) {
+			return true
+		}
+		return path == "time" && f.Sel.Name == "Duration"
+	}
+	return false
+}
+
+// isDurationType tests if the function of a call is the time.Duration type.
+func (e *defaultsExtractor) isDurationType(fun ast.Expr, scope *fileScope) bool {
+	sel, ok := fun.(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+	pkg, ok := sel.X.(*ast.Ident)
+	return ok && scope.imports[pkg.Name] == "time" && sel.Sel.Name == "Duration"
+}
+
+// resolveComposite resolves composite literals: lists of resolvable values,
+// metav1.Duration literals and empty structs.
+func (e *defaultsExtractor) resolveComposite(lit *ast.CompositeLit, scope *fileScope) (defaultValue, bool) {
+	if _, ok := lit.Type.(*ast.ArrayType); ok {
+		list := []interface{}{}
+		for _, elt := range lit.Elts {
+			v, ok := e.resolve(elt, scope)
+			if !ok {
+				return defaultValue{}, false
+			}
+			list = append(list, v.jsonValue())
+		}
+		b, err := json.Marshal(list)
+		if err != nil {
+			return defaultValue{}, false
+		}
+		return defaultValue{text: string(b)}, true
+	}
+
+	if sel, ok := lit.Type.(*ast.SelectorExpr); ok && sel.Sel.Name == "Duration" && len(lit.Elts) == 1 {
+		pkg, ok := sel.X.(*ast.Ident)
+		if ok && scope.imports[pkg.Name] == "k8s.io/apimachinery/pkg/apis/meta/v1" {
+			elt := lit.Elts[0]
+			if kv, ok := elt.(*ast.KeyValueExpr); ok {
+				elt = kv.Value
+			}
+			v, ok := e.resolve(elt, scope)
+			if ok && v.val != nil {
+				v.duration = true
+			}
+			return v, ok
+		}
+	}
+
+	if len(lit.Elts) == 0 {
+		return defaultValue{text: "{}"}, true
+	}
+	return defaultValue{}, false
+}
+
+// resolveValue resolves a constant or a package-level variable in the
+// specified package.
+func (e *defaultsExtractor) resolveValue(pkgPath, name string) (defaultValue, bool) {
+	values, ok := e.values[pkgPath]
+	if !ok {
+		values = e.loadValues(pkgPath)
+	}
+	c, ok := values[name]
+	if !ok {
+		e.missing = pkgPath + "." + name
+		return defaultValue{}, false
+	}
+	if c.expr == nil {
+		return defaultValue{}, false
+	}
+	expr := c.expr
+	// Avoid infinite recursion on (invalid) self-references.
+	c.expr = nil
+	v, ok := e.resolve(expr, c.scope)
+	c.expr = expr
+	return v, ok
+}
+
+// loadValues collects the constant and variable declarations in a package.
+func (e *defaultsExtractor) loadValues(pkgPath string) map[string]*valueDecl {
+	values := map[string]*valueDecl{}
+	e.values[pkgPath] = values
+
+	files, err := e.parsePackage(pkgPath)
+	if err != nil {
+		klog.V(3).Infof("Cannot parse package %s for constants: %v", pkgPath, err)
+		return values
+	}
+	for _, f := range files {
+		scope := e.fileScope(pkgPath, f)
+		for _, decl := range f.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || (gen.Tok != token.CONST && gen.Tok != token.VAR) {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				vs := spec.(*ast.ValueSpec)
+				for i, n := range vs.Names {
+					if i < len(vs.Values) {
+						values[n.Name] = &valueDecl{expr: vs.Values[i], scope: scope}
+					}
+				}
+			}
+		}
+	}
+	return values
+}
+
+// parsePackage parses the non-test Go files of a package.
+func (e *defaultsExtractor) parsePackage(pkgPath string) ([]*ast.File, error) {
+	dir, ok := e.dirs[pkgPath]
+	if !ok {
+		if dir, ok = e.moduleDir(pkgPath); !ok {
+			var err error
+			if dir, err = e.loader.dir(pkgPath); err != nil {
+				return nil, err
+			}
+		}
+		e.dirs[pkgPath] = dir
+	}
+	names, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+	var files []*ast.File
+	for _, name := range names {
+		if strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		// skip files excluded by build constraints, e.g. for other platforms
+		if match, err := build.Default.MatchFile(dir, filepath.Base(name)); err != nil || !match {
+			continue
+		}
+		f, err := parser.ParseFile(e.fset, name, nil, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, f)
+	}
+	return files, nil
+}
+
+// moduleDir returns the directory of a package in a local module checkout.
+// The longest matching module path wins, as for nested modules.
+func (e *defaultsExtractor) moduleDir(pkgPath string) (string, bool) {
+	match := ""
+	for mod := range e.modules {
+		if (pkgPath == mod || strings.HasPrefix(pkgPath, mod+"/")) && len(mod) > len(match) {
+			match = mod
+		}
+	}
+	if match == "" {
+		return "", false
+	}
+	return filepath.Join(e.modules[match], strings.TrimPrefix(pkgPath, match)), true
+}
+
+// fileScope builds the import table for a Go source file.
+func (e *defaultsExtractor) fileScope(pkgPath string, f *ast.File) *fileScope {
+	s := &fileScope{pkgPath: pkgPath, imports: map[string]string{}}
+	for _, imp := range f.Imports {
+		path, err := strconv.Unquote(imp.Path.Value)
+		if err != nil {
+			continue
+		}
+		name := path[strings.LastIndex(path, "/")+1:]
+		if imp.Name != nil {
+			name = imp.Name.Name
+		}
+		s.imports[name] = path
+	}
+	return s
+}
+
+// exprString prints an expression on a single line for diagnostics.
+func (e *defaultsExtractor) exprString(expr ast.Expr) string {
+	var b bytes.Buffer
+	if err := printer.Fprint(&b, e.fset, expr); err != nil {
+		return "<unknown>"
+	}
+	return strings.Join(strings.Fields(b.String()), " ")
+}
@@ -0,0 +1,361 @@
+package generators
+
+import (
+	"go/constant"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"k8s.io/gengo/types"
+)
+
+const testAPISource = `package api
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	DefaultPort    = 8080
+	DefaultAddress = "0.0.0.0"
+	BaseTimeout    = 2 * time.Second
+)
+
+var DefaultFeatures = []string{"a", "b"}
+
+func SetDefaults_Config(obj *Config) {
+	if obj.Address == "" {
+		obj.Address = DefaultAddress
+	}
+	if obj.Port == 0 {
+		obj.Port = DefaultPort
+	} else {
+		obj.Port = 1
+	}
+	if obj.Workers == nil {
+		obj.Workers = ptr.To[int32](7 / 2)
+	}
+	if obj.Ratio == nil {
+		obj.Ratio = ptr.To(7.0 / 2)
+	}
+	if obj.Shift == 0 {
+		obj.Shift = 1 << 4
+	}
+	if obj.Timeout == nil {
+		obj.Timeout = &metav1.Duration{Duration: 3 * BaseTimeout}
+	}
+	if obj.Interval == 0 {
+		obj.Interval = time.Duration(90) * time.Second
+	}
+	if obj.Features == nil {
+		obj.Features = DefaultFeatures
+	}
+	if obj.Enabled == nil {
+		obj.Enabled = ptr.To(DefaultPort > 80)
+	}
+	obj.DivByZero = DefaultPort / 0
+	obj.Mismatched = DefaultAddress + DefaultPort
+	obj.Copied = obj.Address
+	name := "runtime"
+	obj.Local = name
+}
+`
+
+const testDefaultsSource = `package defaults
+
+import (
+	api "example.com/api"
+)
+
+func SetDefaults_Config(obj *api.Config) {
+	if obj.External == "" {
+		obj.External = "external"
+	}
+	if obj.Port == 0 {
+		obj.Port = 9090
+	}
+}
+`
+
+// testConfigType builds the gengo type of the Config struct in the test
+// package.
+func testConfigType() *types.Type {
+	duration := &types.Type{
+		Name: types.Name{Package: "k8s.io/apimachinery/pkg/apis/meta/v1", Name: "Duration"},
+		Kind: types.Struct,
+	}
+	t := &types.Type{
+		Name: types.Name{Package: "example.com/api", Name: "Config"},
+		Kind: types.Struct,
+	}
+	for _, name := range []string{"Address", "External", "Copied", "Local", "Mismatched"} {
+		t.Members = append(t.Members, types.Member{Name: name, Type: types.String})
+	}
+	for _, name := range []string{"Port", "Shift", "DivByZero"} {
+		t.Members = append(t.Members, types.Member{Name: name, Type: types.Int32})
+	}
+	t.Members = append(t.Members,
+		types.Member{Name: "Workers", Type: &types.Type{Kind: types.Pointer, Elem: types.Int32}},
+		types.Member{Name: "Ratio", Type: &types.Type{Kind: types.Pointer, Elem: types.Float64}},
+		types.Member{Name: "Enabled", Type: &types.Type{Kind: types.Pointer, Elem: types.Bool}},
+		types.Member{Name: "Timeout", Type: &types.Type{Kind: types.Pointer, Elem: duration}},
+		types.Member{Name: "Interval", Type: types.Int64},
+		types.Member{Name: "Features", Type: &types.Type{Kind: types.Slice, Elem: types.String}},
+	)
+	return t
+}
+
+func writeTestPackage(t *testing.T, source string) string {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "defaults.go"), []byte(source), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func TestExtractDefaults(t *testing.T) {
+	defaults := map[string]string{}
+	e := newDefaultsExtractor(defaults, nil)
+	e.dirs["example.com/api"] = writeTestPackage(t, testAPISource)
+	e.dirs["example.com/defaults"] = writeTestPackage(t, testDefaultsSource)
+	config := testConfigType()
+	e.apiTypes[config.Name.String()] = config
+
+	unresolved := 0
+	for _, path := range []string{"example.com/api", "example.com/defaults"} {
+		files, err := e.parsePackage(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, f := range files {
+			unresolved += e.extractFile(path, f)
+		}
+	}
+
+	tests := []struct {
+		Name     string
+		Field    string
+		Expected string
+	}{
+		{"string constant", "Address", "0.0.0.0"},
+		{"first assignment wins", "Port", "8080"},
+		{"truncated integer division", "Workers", "3"},
+		{"float division", "Ratio", "3.5"},
+		{"shift", "Shift", "16"},
+		{"duration from constants", "Timeout", "6s"},
+		{"duration conversion", "Interval", "1m30s"},
+		{"list variable", "Features", `["a","b"]`},
+		{"comparison", "Enabled", "true"},
+		{"separate defaults package", "External", "external"},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			key := "example.com/api.Config." + test.Field
+			if v, ok := defaults[key]; !ok || v != test.Expected {
+				t.Errorf("Expected %q for %s, got %q (found: %v)", test.Expected, test.Field, v, ok)
+			}
+		})
+	}
+
+	for _, field := range []string{"DivByZero", "Mismatched", "Copied", "Local"} {
+		if v, ok := defaults["example.com/api.Config."+field]; ok {
+			t.Errorf("Expected no default for %s, got %q", field, v)
+		}
+	}
+	// the values computed at runtime are not counted
+	if unresolved != 2 {
+		t.Errorf("Expected 2 unresolved assignments, got %d", unresolved)
+	}
+}
+
+func TestBinaryOp(t *testing.T) {
+	tests := []struct {
+		Name     string
+		X        constant.Value
+		Op       token.Token
+		Y        constant.Value
+		Expected string
+		OK       bool
+	}{
+		{"integer division", constant.MakeInt64(7), token.QUO, constant.MakeInt64(2), "3", true},
+		{"negative integer division", constant.MakeInt64(-7), token.QUO, constant.MakeInt64(2), "-3", true},
+		{"float division", constant.MakeFloat64(7), token.QUO, constant.MakeInt64(2), "3.5", true},
+		{"remainder", constant.MakeInt64(7), token.REM, constant.MakeInt64(4), "3", true},
+		{"division by zero", constant.MakeInt64(7), token.QUO, constant.MakeInt64(0), "", false},
+		{"remainder by zero", constant.MakeInt64(7), token.REM, constant.MakeInt64(0), "", false},
+		{"concatenation", constant.MakeString("a"), token.ADD, constant.MakeString("b"), `"ab"`, true},
+		{"mismatched kinds", constant.MakeString("a"), token.ADD, constant.MakeInt64(1), "", false},
+		{"mismatched comparison", constant.MakeString("a"), token.EQL, constant.MakeInt64(1), "", false},
+		{"comparison", constant.MakeInt64(1), token.LSS, constant.MakeFloat64(1.5), "true", true},
+		{"shift", constant.MakeInt64(1), token.SHL, constant.MakeInt64(10), "1024", true},
+		{"negative shift", constant.MakeInt64(1), token.SHL, constant.MakeInt64(-1), "", false},
+		{"invalid operator", constant.MakeBool(true), token.ADD, constant.MakeBool(false), "", false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			v, ok := binaryOp(test.X, test.Op, test.Y)
+			if ok != test.OK {
+				t.Fatalf("Expected ok to be %v, got %v", test.OK, ok)
+			}
+			if ok && v.String() != test.Expected {
+				t.Errorf("Expected %s, got %s", test.Expected, v.String())
+			}
+		})
+	}
+}
+
+func TestUnaryOp(t *testing.T) {
+	if v, ok := unaryOp(token.SUB, constant.MakeInt64(3)); !ok || v.String() != "-3" {
+		t.Errorf("Expected -3, got %v", v)
+	}
+	if _, ok := unaryOp(token.SUB, constant.MakeString("a")); ok {
+		t.Errorf("Expected negating a string to fail")
+	}
+}
+
+const testOrderSource = `package api
+
+func SetDefaults_Config(obj *Config) {
+	if obj.Address == "" {
+		obj.Address = "first"
+		obj.Address = "second"
+	}
+	if obj.Port == 0 {
+		if obj.Shift == 0 {
+			obj.Port = 1
+		} else {
+			obj.Port = 2
+		}
+	}
+}
+
+func SetDefaults_ConfigAgain(obj *Config) {
+	obj.Address = "later function"
+	obj.Shift = 3
+}
+`
+
+func TestFirstAssignmentWins(t *testing.T) {
+	defaults := map[string]string{}
+	e := newDefaultsExtractor(defaults, nil)
+	e.dirs["example.com/api"] = writeTestPackage(t, testOrderSource)
+	config := testConfigType()
+	e.apiTypes[config.Name.String()] = config
+
+	files, err := e.parsePackage("example.com/api")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range files {
+		e.extractFile("example.com/api", f)
+	}
+
+	tests := []struct {
+		Name     string
+		Field    string
+		Expected string
+	}{
+		{"same block", "Address", "first"},
+		{"if and else branches", "Port", "1"},
+		{"later function", "Shift", "3"},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			key := "example.com/api.Config." + test.Field
+			if v := defaults[key]; v != test.Expected {
+				t.Errorf("Expected %q for %s, got %q", test.Expected, test.Field, v)
+			}
+		})
+	}
+}
+
+func TestResolveConstantFolding(t *testing.T) {
+	e := newDefaultsExtractor(map[string]string{}, nil)
+	e.dirs["example.com/api"] = writeTestPackage(t, testAPISource)
+	scope := &fileScope{pkgPath: "example.com/api", imports: map[string]string{"time": "time"}}
+
+	tests := []struct {
+		Name     string
+		Expr     string
+		Expected string
+		OK       bool
+	}{
+		{"constant arithmetic", "DefaultPort * 2 + 1", "16161", true},
+		{"negated constant", "-DefaultPort", "-8080", true},
+		{"string concatenation", `DefaultAddress + ":80"`, "0.0.0.0:80", true},
+		{"duration", "BaseTimeout / 4", "500ms", true},
+		// the operations below panic in go/constant and are recovered
+		{"boolean addition", "true + false", "", false},
+		{"complement of a float", "^1.5", "", false},
+		{"negated string", "-DefaultAddress", "", false},
+		{"division by zero", "DefaultPort / 0", "", false},
+		{"undeclared constant", "Undeclared + 1", "", false},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			expr, err := parser.ParseExpr(test.Expr)
+			if err != nil {
+				t.Fatal(err)
+			}
+			v, ok := e.resolve(expr, scope)
+			if ok != test.OK {
+				t.Fatalf("Expected ok to be %v, got %v", test.OK, ok)
+			}
+			if ok && v.String() != test.Expected {
+				t.Errorf("Expected %s, got %s", test.Expected, v.String())
+			}
+		})
+	}
+}
+
+func TestParsePackageBuildConstraints(t *testing.T) {
+	otherOS := "windows"
+	if runtime.GOOS == otherOS {
+		otherOS = "linux"
+	}
+	dir := t.TempDir()
+	// the excluded files sort before defaults.go, so their assignments would
+	// win if they were parsed
+	files := map[string]string{
+		"a_ignored.go":              "//go:build ignore\n\npackage api\n\nfunc SetDefaults_Config(obj *Config) { obj.Port = 1 }\n",
+		"a_" + otherOS + ".go":      "package api\n\nfunc SetDefaults_Config(obj *Config) { obj.Port = 2 }\n",
+		"a_test.go":                 "package api\n\nfunc SetDefaults_Config(obj *Config) { obj.Port = 3 }\n",
+		"a_" + runtime.GOOS + ".go": "package api\n\nfunc SetDefaults_Platform(obj *Config) { obj.Address = \"platform\" }\n",
+		"defaults.go":               "package api\n\nfunc SetDefaults_Config(obj *Config) { obj.Port = 4 }\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	defaults := map[string]string{}
+	e := newDefaultsExtractor(defaults, nil)
+	e.dirs["example.com/api"] = dir
+	config := testConfigType()
+	e.apiTypes[config.Name.String()] = config
+
+	parsed, err := e.parsePackage("example.com/api")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(parsed) != 2 {
+		t.Errorf("Expected 2 files to be parsed, got %d", len(parsed))
+	}
+	for _, f := range parsed {
+		e.extractFile("example.com/api", f)
+	}
+	if v := defaults["example.com/api.Config.Port"]; v != "4" {
+		t.Errorf("Expected the default from the matching file, got %q", v)
+	}
+	if v := defaults["example.com/api.Config.Address"]; v != "platform" {
+		t.Errorf("Expected the default from the file for %s, got %q", runtime.GOOS, v)
+	}
+}
@@ -0,0 +1,89 @@
+package generators
+
+import (
+	"html/template"
+	"sort"
+	"strings"
+
+	"k8s.io/gengo/types"
+)
+
+// enumValue is a constant declared for a string alias type, e.g. a value of
+// 'ProxyMode'.
+type enumValue struct {
+	// Name is the Go identifier of the constant.
+	Name string
+
+	// Value is the (unquoted) value of the constant.
+	Value string
+
+	// CommentLines is the doc comment of the constant.
+	CommentLines []string
+
+	index *typeIndex
+}
+
+// GetComment returns the rendered HTML output from the constant comment.
+func (v *enumValue) GetComment() template.HTML {
+	return v.index.renderComments(v.CommentLines)
+}
+
+// AllowedValues returns the constants declared for a string alias type in
+// the same Go package, sorted by value. It returns nil for other types or when
+// there are no constants of the type. Constants sharing a value are collapsed
+// into one, see uniqueValues.
+func (t *apiType) AllowedValues() []*enumValue {
+	t = t.deref()
+	if t.Kind != types.Alias || t.Underlying == nil ||
+		t.Underlying.Kind != types.Builtin || t.Underlying.Name.Name != "string" {
+		return nil
+	}
+	p := t.index.typePkgMap[t.String()]
+	if p == nil {
+		return nil
+	}
+
+	var result []*enumValue
+	for _, gopkg := range p.GoPackages {
+		if gopkg.Path != t.Name.Package {
+			continue
+		}
+		for _, c := range gopkg.Constants {
+			if c.Underlying == nil || c.Underlying.Name != t.Name || c.ConstValue == nil {
+				continue
+			}
+			result = append(result, &enumValue{
+				Name:         c.Name.Name,
+				Value:        *c.ConstValue,
+				CommentLines: c.CommentLines,
+				index:        t.index,
+			})
+		}
+	}
+	return uniqueValues(result)
+}
+
+// uniqueValues sorts the constants by value and keeps one constant for each
+// value. A constant like 'DefaultEncryptionAlgorithm', which only aliases
+// another value, gives way to the other constants of the same value.
+func uniqueValues(values []*enumValue) []*enumValue {
+	sort.Slice(values, func(i, j int) bool {
+		if values[i].Value != values[j].Value {
+			return values[i].Value < values[j].Value
+		}
+		return values[i].Name < values[j].Name
+	})
+
+	var result []*enumValue
+	for _, v := range values {
+		n := len(result)
+		if n == 0 || result[n-1].Value != v.Value {
+			result = append(result, v)
+			continue
+		}
+		if strings.HasPrefix(result[n-1].Name, "Default") && !strings.HasPrefix(v.Name, "Default") {
+			result[n-1] = v
+		}
+	}
+	return result
+}
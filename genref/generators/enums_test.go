@@ -0,0 +1,57 @@
+package generators
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestUniqueValues(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Input    []*enumValue
+		Expected []string
+	}{
+		{
+			Name: "distinct values are sorted",
+			Input: []*enumValue{
+				{Name: "ProxyModeNFTables", Value: "nftables"},
+				{Name: "ProxyModeIPTables", Value: "iptables"},
+			},
+			Expected: []string{"ProxyModeIPTables=iptables", "ProxyModeNFTables=nftables"},
+		},
+		{
+			Name: "default alias gives way",
+			Input: []*enumValue{
+				{Name: "EncryptionAlgorithmRSA2048", Value: "RSA-2048"},
+				{Name: "DefaultEncryptionAlgorithm", Value: "RSA-2048"},
+				{Name: "EncryptionAlgorithmECDSAP256", Value: "ECDSA-P256"},
+			},
+			Expected: []string{"EncryptionAlgorithmECDSAP256=ECDSA-P256", "EncryptionAlgorithmRSA2048=RSA-2048"},
+		},
+		{
+			Name: "first name wins otherwise",
+			Input: []*enumValue{
+				{Name: "ModeB", Value: "b"},
+				{Name: "ModeA", Value: "b"},
+			},
+			Expected: []string{"ModeA=b"},
+		},
+		{
+			Name:     "empty",
+			Input:    nil,
+			Expected: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			var result []string
+			for _, v := range uniqueValues(test.Input) {
+				result = append(result, v.Name+"="+v.Value)
+			}
+			if !reflect.DeepEqual(result, test.Expected) {
+				t.Errorf("Expected %v, got %v", test.Expected, result)
+			}
+		})
+	}
+}
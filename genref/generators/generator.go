@@ -0,0 +1,738 @@
+package generators
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"html/template"
+	"io"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	texttemplate "text/template"
+
+	"k8s.io/gengo/types"
+	"k8s.io/klog/v2"
+)
+
+const docCommentForceIncludes = "// +gencrdrefdocs:force"
+
+// Options controls what is generated from the configuration and how.
+type Options struct {
+	// Format is the output format, one of 'html', 'markdown', 'json', 'yaml'
+	// and 'jsonschema'.
+	Format string
+
+	// TemplateDir is the directory where the 'html' and 'markdown' template
+	// directories are found. The current directory is used if empty.
+	TemplateDir string
+
+	// OutputPath is the directory prefixed to the paths of the results.
+	OutputPath string
+
+	// Include and Exclude select the API definitions to process by name.
+	Include []string
+	Exclude []string
+
+	// Examples includes example YAML skeletons for top-level kinds in the
+	// output, and generates them as standalone files.
+	Examples bool
+
+	// Migration generates migration reports between the versions of each API
+	// instead of the API references.
+	Migration bool
+
+	// Lang is the language of the translations to use. The output files are
+	// written into the <OutputPath>/<Lang> directory.
+	Lang string
+
+	// TranslationDir is the directory of the translation overlay files, which
+	// are read from its <Lang> subdirectory.
+	TranslationDir string
+
+	// Releases overrides the releases in the configuration.
+	Releases []Release
+
+	// HideDeprecated hides the deprecated fields from the output.
+	HideDeprecated bool
+
+	// KubernetesVersion overrides the Kubernetes release in the configuration.
+	KubernetesVersion string
+
+	// Graphs writes the type reference graph of each API package as Graphviz
+	// DOT and Mermaid files.
+	Graphs bool
+
+	// EmbedGraphs embeds the Mermaid diagram of each API package in the
+	// page. It is supported for the 'markdown' format.
+	EmbedGraphs bool
+
+	// Index generates an index page listing the generated API references.
+	// It is supported for the 'html' and 'markdown' formats.
+	Index bool
+
+	// Workers is the number of API definitions processed concurrently. The
+	// number of CPUs is used if not positive.
+	Workers int
+}
+
+// Result is an output file produced by the generator.
+type Result struct {
+	// Name is the name of the API definition the result is generated from.
+	Name string
+
+	// Path is the path of the output file, prefixed by Options.OutputPath.
+	Path string
+
+	// Content is the rendered content of the output file.
+	Content []byte
+}
+
+// Generator generates the API references for the definitions in a
+// configuration.
+type Generator struct {
+	config Config
+	opts   Options
+
+	// The templates for the 'html' or the 'markdown' format
+	htmlTemplates *template.Template
+	textTemplates *texttemplate.Template
+
+	gitCommit string
+
+	// The compiled external package rules, and the issues found when
+	// building the links to external types
+	linkRules []*linkRule
+	// linkVars are the variables for all the 'target' templates
+	linkVars map[string]interface{}
+	links    *linkReport
+
+	// The compiled visibility rules
+	visibilityRules []*visibilityRule
+
+	// history is the history of the types and fields in the releases, if any
+	history *history
+
+	// The translations of the language, if any, and the comments found
+	// untranslated or stale
+	overlay      map[string]translation
+	translations *translationReport
+}
+
+// typeIndex holds the lookup tables built from the API packages of an API
+// definition.
+type typeIndex struct {
+	*Generator
+
+	// api is the name of the API definition
+	api string
+
+	// includes are the shared packages of the API definition
+	includes []string
+
+	// Map from type definition to the API package
+	typePkgMap map[string]*apiPackage
+
+	// Map from type to the types referencing it
+	references map[string][]*apiType
+
+	// Map from member (PackagePath.Type.Field) to the default value found in
+	// the defaulting functions
+	defaultValues map[string]string
+}
+
+// New creates a Generator, loading the templates for the output format.
+func New(cfg *Config, opts Options) (*Generator, error) {
+	g := &Generator{
+		config: *cfg,
+		opts:   opts,
+	}
+
+	glob := filepath.Join(opts.TemplateDir, opts.Format, "*.tpl")
+	var err error
+	switch opts.Format {
+	case "html":
+		g.htmlTemplates, err = template.New("").ParseGlob(glob)
+	case "markdown":
+		g.textTemplates, err = texttemplate.New("").ParseGlob(glob)
+	case "json", "yaml", "jsonschema":
+		// serialized formats don't need templates
+	default:
+		return nil, fmt.Errorf("unsupported format '%s' specified", opts.Format)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot load the templates for format '%s': %w", opts.Format, err)
+	}
+	if opts.Migration && opts.Format == "jsonschema" {
+		return nil, fmt.Errorf("format '%s' is not supported for migration reports", opts.Format)
+	}
+	if opts.EmbedGraphs && opts.Format != "markdown" {
+		return nil, fmt.Errorf("format '%s' is not supported for embedded graphs", opts.Format)
+	}
+	if opts.Index && opts.Format != "html" && opts.Format != "markdown" {
+		return nil, fmt.Errorf("format '%s' is not supported for the index page", opts.Format)
+	}
+
+	gitCommit, _ := exec.Command("git", "rev-parse", "--short", "HEAD").Output()
+	g.gitCommit = strings.TrimSpace(string(gitCommit))
+
+	if opts.Lang != "" {
+		if g.overlay, err = loadOverlay(opts.TranslationDir, opts.Lang); err != nil {
+			return nil, err
+		}
+		g.opts.OutputPath = filepath.Join(opts.OutputPath, opts.Lang)
+	}
+	if g.visibilityRules, err = compileVisibilityRules(cfg.VisibilityRules); err != nil {
+		return nil, err
+	}
+	if g.linkVars, err = linkVars(cfg, opts.KubernetesVersion); err != nil {
+		return nil, err
+	}
+	g.linkRules = compileLinkRules(cfg.ExternalPackages)
+	g.links = newLinkReport(g.linkRules)
+	return g, nil
+}
+
+// Generate creates a Generator and runs it.
+func Generate(ctx context.Context, cfg *Config, opts Options) ([]Result, error) {
+	g, err := New(cfg, opts)
+	if err != nil {
+		return nil, err
+	}
+	return g.Generate(ctx)
+}
+
+// Generate processes the selected API definitions and renders the results.
+// The definitions are processed concurrently, and the results are returned
+// in the order of the definitions in the configuration. An API definition
+// that fails does not stop the others from being processed; the errors are
+// joined into the returned error, together with the results produced.
+func (g *Generator) Generate(ctx context.Context) ([]Result, error) {
+	var defs []APIDefinition
+	for _, item := range g.config.Definitions {
+		if !item.Skip && g.selected(item.Name) {
+			defs = append(defs, item)
+		}
+	}
+
+	loader, err := newModuleLoader(defs)
+	if err != nil {
+		return nil, err
+	}
+	defer loader.close()
+
+	cache, err := newParseCache(loader, apiDirs(defs))
+	if err != nil {
+		return nil, err
+	}
+	g.links = newLinkReport(g.linkRules)
+	if g.overlay != nil {
+		g.translations = newTranslationReport()
+	}
+
+	releases := g.opts.Releases
+	if len(releases) == 0 {
+		releases = g.config.Releases
+	}
+	if g.history, err = loadHistory(releases, defs); err != nil {
+		return nil, err
+	}
+
+	outputs := make([]apiOutput, len(defs))
+	err = g.parallel(ctx, len(defs), func(i int) {
+		outputs[i] = g.processAPI(cache, defs[i])
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	g.indexAPIs(outputs)
+
+	if !g.opts.Migration {
+		err = g.parallel(ctx, len(outputs), func(i int) {
+			out := &outputs[i]
+			if out.err != nil {
+				return
+			}
+			base := fmt.Sprintf("%s/%s.%s", g.opts.OutputPath, out.api.Definition.Name, out.api.Version)
+			out.results, out.err = g.renderAPI(out.api.Packages, base)
+			if out.err != nil {
+				out.err = fmt.Errorf("cannot render API %s: %w", out.api.Definition.Name, out.err)
+			}
+			for j := range out.results {
+				out.results[j].Name = out.api.Definition.Name
+			}
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var results []Result
+	var errs []error
+	var apis []processedAPI
+	for _, out := range outputs {
+		if out.err != nil {
+			errs = append(errs, out.err)
+			continue
+		}
+		apis = append(apis, out.api)
+		results = append(results, out.results...)
+	}
+
+	if g.opts.Migration {
+		for _, r := range migrationReports(apis) {
+			fn := fmt.Sprintf("%s/%s.%s-to-%s", g.opts.OutputPath, r.Name, r.From, r.To) + outputExtension(g.opts.Format)
+			var b bytes.Buffer
+			if err := g.renderMigration(&b, r); err != nil {
+				errs = append(errs, fmt.Errorf("cannot render migration report %s: %w", fn, err))
+				continue
+			}
+			results = append(results, Result{Name: r.Name, Path: fn, Content: b.Bytes()})
+		}
+	} else if g.opts.Index && len(apis) > 0 {
+		index, err := g.renderIndex(apis)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("cannot render the index page: %w", err))
+		} else {
+			results = append(results, index)
+		}
+	}
+
+	return results, errors.Join(errs...)
+}
+
+// parallel calls fn for 0 to n-1 using the configured number of workers. It
+// stops early and returns an error if the context is done.
+func (g *Generator) parallel(ctx context.Context, n int, fn func(i int)) error {
+	workers := g.opts.Workers
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				fn(i)
+			}
+		}()
+	}
+feed:
+	for i := 0; i < n; i++ {
+		select {
+		case jobs <- i:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+	return ctx.Err()
+}
+
+// apiOutput is the outcome of processing an API definition.
+type apiOutput struct {
+	api     processedAPI
+	index   *typeIndex
+	results []Result
+	err     error
+}
+
+// selected tests if an API definition is selected by the include and exclude
+// options.
+func (g *Generator) selected(name string) bool {
+	if len(g.opts.Exclude) > 0 && containsString(g.opts.Exclude, name) {
+		return false
+	}
+	if len(g.opts.Include) > 0 && !containsString(g.opts.Include, name) {
+		return false
+	}
+	return true
+}
+
+// apiDir returns the directory of the main API packages of a definition.
+func apiDir(item APIDefinition) string {
+	return strings.Join([]string{item.Package, item.Path}, "/")
+}
+
+// apiDirs returns the directories to parse for the API definitions, including
+// the shared packages, without duplicates.
+func apiDirs(defs []APIDefinition) []string {
+	var dirs []string
+	for _, item := range defs {
+		for _, dir := range append([]string{apiDir(item)}, item.Includes...) {
+			if !containsString(dirs, dir) {
+				dirs = append(dirs, dir)
+			}
+		}
+	}
+	return dirs
+}
+
+// processAPI builds the API packages for an API definition from the parsed Go
+// packages. The type index of the definition is filled with its own types.
+func (g *Generator) processAPI(cache *parseCache, item APIDefinition) apiOutput {
+	gopkgs, err := g.apiGoPackages(cache, item)
+	if err != nil {
+		return apiOutput{err: fmt.Errorf("cannot process API %s: %w", item.Name, err)}
+	}
+
+	idx := newTypeIndex(g, item.Name)
+	idx.includes = item.Includes
+	pkgs, err := idx.combineAPIPackages(gopkgs, item.Title, item.MainPackage, item.Resources)
+	if err != nil {
+		return apiOutput{err: fmt.Errorf("cannot process API %s: %w", item.Name, err)}
+	}
+
+	extractDefaults(pkgs, item, idx.defaultValues, cache.loader)
+
+	for _, p := range pkgs {
+		p.Title = idx.translatedTitle(p.GoPackages[0].Path, p.Title)
+	}
+
+	// Update typePkgMap and references map
+	for _, p := range pkgs {
+		for _, t := range p.Types {
+			idx.typePkgMap[t.String()] = p
+			for _, m := range t.Members {
+				rt := t.wrap(m.Type).deref().String()
+				idx.references[rt] = append(idx.references[rt], t)
+			}
+		}
+	}
+
+	segments := strings.Split(item.Path, "/")
+	version := segments[len(segments)-1]
+	return apiOutput{
+		api:   processedAPI{Definition: item, Version: version, Packages: pkgs},
+		index: idx,
+	}
+}
+
+// apiGoPackages returns the Go packages of an API definition, including the
+// shared ones.
+func (g *Generator) apiGoPackages(cache *parseCache, item APIDefinition) ([]*types.Package, error) {
+	path := apiDir(item)
+	gopkgs, err := cache.get(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(gopkgs) == 0 {
+		return nil, fmt.Errorf("no API packages found in %s", path)
+	}
+
+	for _, p := range item.Includes {
+		extra, err := cache.get(p)
+		if err != nil {
+			return nil, err
+		}
+		gopkgs = append(gopkgs, extra...)
+	}
+	return gopkgs, nil
+}
+
+func newTypeIndex(g *Generator, api string) *typeIndex {
+	return &typeIndex{
+		Generator:     g,
+		api:           api,
+		typePkgMap:    make(map[string]*apiPackage),
+		references:    make(map[string][]*apiType),
+		defaultValues: make(map[string]string),
+	}
+}
+
+// indexAPIs fills the type index of each processed API definition with the
+// types of the definitions before it in the configuration, the same as if the
+// definitions were processed one by one. For example, the types shared by
+// several definitions list the types referencing them in all of them.
+// Migration reports are generated after all the definitions are processed,
+// so the index of all the definitions is used for them.
+func (g *Generator) indexAPIs(outputs []apiOutput) {
+	all := newTypeIndex(g, "")
+	for _, out := range outputs {
+		if out.err != nil {
+			continue
+		}
+		all.merge(out.index)
+		if !g.opts.Migration {
+			out.index.copyFrom(all)
+		}
+	}
+	if g.opts.Migration {
+		for _, out := range outputs {
+			if out.err == nil {
+				out.index.typePkgMap = all.typePkgMap
+				out.index.references = all.references
+				out.index.defaultValues = all.defaultValues
+			}
+		}
+	}
+}
+
+// merge adds the entries of another index. The packages of the types from the
+// other index take precedence, while the default values already known are
+// kept.
+func (idx *typeIndex) merge(other *typeIndex) {
+	for k, p := range other.typePkgMap {
+		idx.typePkgMap[k] = p
+	}
+	for k, refs := range other.references {
+		idx.references[k] = append(idx.references[k], refs...)
+	}
+	for k, v := range other.defaultValues {
+		if _, found := idx.defaultValues[k]; !found {
+			idx.defaultValues[k] = v
+		}
+	}
+}
+
+// copyFrom replaces the entries with a copy of another index. The referencing
+// types are rebound to this index.
+func (idx *typeIndex) copyFrom(other *typeIndex) {
+	idx.typePkgMap = make(map[string]*apiPackage, len(other.typePkgMap))
+	for k, p := range other.typePkgMap {
+		idx.typePkgMap[k] = p
+	}
+	rebound := make(map[*apiType]*apiType)
+	idx.references = make(map[string][]*apiType, len(other.references))
+	for k, refs := range other.references {
+		list := make([]*apiType, 0, len(refs))
+		for _, t := range refs {
+			if _, ok := rebound[t]; !ok {
+				rebound[t] = &apiType{Type: t.Type, index: idx}
+			}
+			list = append(list, rebound[t])
+		}
+		idx.references[k] = list
+	}
+	idx.defaultValues = make(map[string]string, len(other.defaultValues))
+	for k, v := range other.defaultValues {
+		idx.defaultValues[k] = v
+	}
+}
+
+// parseCache holds the Go packages parsed from each directory. All the
+// directories are parsed by a single gengo builder, so that the packages
+// shared by the API definitions and their dependencies are parsed only once.
+type parseCache struct {
+	loader   *moduleLoader
+	packages map[string][]*types.Package
+	errs     map[string]error
+}
+
+// newParseCache parses the given directories for API packages. The packages
+// are resolved by the loader first, so that those that cannot be resolved
+// are reported instead of being skipped silently by gengo.
+func newParseCache(loader *moduleLoader, dirs []string) (*parseCache, error) {
+	c := &parseCache{
+		loader:   loader,
+		packages: make(map[string][]*types.Package),
+		errs:     make(map[string]error),
+	}
+	resolveErrs, err := loader.resolve(dirs)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := loader.newBuilder()
+	if err != nil {
+		return nil, err
+	}
+	for _, dir := range dirs {
+		if err := resolveErrs[dir]; err != nil {
+			c.errs[dir] = err
+			continue
+		}
+		klog.V(0).Infof("Parsing go packages in %s", dir)
+		if err := b.AddDirRecursive(dir); err != nil {
+			c.errs[dir] = err
+		}
+	}
+	scan, err := b.FindTypes()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse pkgs and types: %w", err)
+	}
+	for _, dir := range dirs {
+		if _, failed := c.errs[dir]; !failed {
+			c.packages[dir] = apiPackages(scan, dir)
+		}
+	}
+	return c, nil
+}
+
+// get returns the API packages parsed from a directory. The cache is not
+// modified after it is created, so it is safe for concurrent use.
+func (c *parseCache) get(dir string) ([]*types.Package, error) {
+	if err, failed := c.errs[dir]; failed {
+		return nil, err
+	}
+	return c.packages[dir], nil
+}
+
+// apiPackages returns the package found in a directory if it is an API
+// package, i.e. one that has types or is forcibly included.
+func apiPackages(scan types.Universe, dir string) []*types.Package {
+	gopkg, ok := scan[dir]
+	if !ok {
+		return nil
+	}
+	klog.V(5).Infof("trying package=%s groupName=%s", dir, groupName(gopkg))
+	klog.V(6).Infof("num types=%d", len(gopkg.Types))
+	// Do not pick up packages that are in vendor/ as API packages.
+	if isVendorPackage(gopkg) {
+		klog.Warningf("Ignoring vendor package '%v'", dir)
+		return nil
+	}
+	if len(gopkg.Types) == 0 && !containsString(gopkg.DocComments, docCommentForceIncludes) {
+		return nil
+	}
+	klog.V(5).Infof("Using package=%s", dir)
+	return []*types.Package{gopkg}
+}
+
+// combineAPIPackages groups the Go packages by the <apiGroup+apiVersion> they
+// offer, and combines the types in them.
+func (idx *typeIndex) combineAPIPackages(pkgs []*types.Package, title string, mainPkg string, resources []string) ([]*apiPackage, error) {
+	pkgMap := make(map[string]*apiPackage)
+	re := `^v\d+((alpha|beta)\d+)?$`
+
+	for _, gopkg := range pkgs {
+		group := groupName(gopkg)
+		// assumes basename (i.e. "v1" in "core/v1") is apiVersion
+		version := gopkg.Name
+
+		if !regexp.MustCompile(re).MatchString(version) {
+			return nil, fmt.Errorf("cannot infer apiVersion for package %s (basename '%q' is not recognizable)", gopkg.Path, version)
+		}
+
+		typeList := make([]*apiType, 0, len(gopkg.Types))
+		for _, t := range gopkg.Types {
+			typeList = append(typeList, &apiType{Type: *t, index: idx})
+		}
+
+		id := fmt.Sprintf("%s/%s", group, version)
+		v, ok := pkgMap[id]
+		if !ok {
+			isMain := true
+			if len(mainPkg) > 0 && group != mainPkg {
+				isMain = false
+			}
+
+			pkgMap[id] = &apiPackage{
+				apiGroup:   group,
+				apiVersion: version,
+				Types:      typeList,
+				GoPackages: []*types.Package{gopkg},
+				Title:      title,
+				IsMain:     isMain,
+				Resources:  resources,
+				index:      idx,
+			}
+		} else {
+			v.Types = append(v.Types, typeList...)
+			v.GoPackages = append(v.GoPackages, gopkg)
+		}
+	}
+	// Sort this map
+	packageIds := make([]string, 0, len(pkgMap))
+	out := make([]*apiPackage, 0, len(pkgMap))
+	for k := range pkgMap {
+		packageIds = append(packageIds, k)
+	}
+	sort.Strings(packageIds)
+	for _, key := range packageIds {
+		out = append(out, pkgMap[key])
+	}
+	return out, nil
+}
+
+// renderAPI renders the reference for an API definition. The base is the
+// output path without the extension.
+func (g *Generator) renderAPI(pkgs []*apiPackage, base string) ([]Result, error) {
+	var results []Result
+	if g.opts.Format == "jsonschema" {
+		// one schema file per kind is generated into this directory
+		schemas, err := schemaResults(pkgs, base)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, schemas...)
+	} else {
+		var b bytes.Buffer
+		if err := g.render(&b, pkgs); err != nil {
+			return nil, fmt.Errorf("failed to render the result: %w", err)
+		}
+		results = append(results, Result{Path: base + outputExtension(g.opts.Format), Content: b.Bytes()})
+	}
+
+	if g.opts.Examples {
+		results = append(results, skeletonResults(pkgs, base)...)
+	}
+	if g.opts.Graphs {
+		results = append(results, graphResults(pkgs, base)...)
+	}
+	return results, nil
+}
+
+// render is the render procedure for templating.
+func (g *Generator) render(w io.Writer, pkgs []*apiPackage) error {
+	if g.opts.Format == "json" || g.opts.Format == "yaml" {
+		return renderData(w, newAPIDocument(pkgs), g.opts.Format)
+	}
+
+	params := map[string]interface{}{
+		"packages":  pkgs,
+		"config":    g.config,
+		"gitCommit": g.gitCommit,
+	}
+	return g.executeTemplate(w, "packages", params)
+}
+
+// executeTemplate executes the named template of the output format.
+func (g *Generator) executeTemplate(w io.Writer, name string, params interface{}) error {
+	var err error
+	if g.htmlTemplates != nil {
+		err = g.htmlTemplates.ExecuteTemplate(w, name, params)
+	} else {
+		err = g.textTemplates.ExecuteTemplate(w, name, params)
+	}
+
+	if err != nil {
+		return fmt.Errorf("template execution error: %w", err)
+	}
+
+	return nil
+}
+
+// renderMigration renders a migration report.
+func (g *Generator) renderMigration(w io.Writer, r *migrationReport) error {
+	if g.opts.Format == "json" || g.opts.Format == "yaml" {
+		return renderData(w, r, g.opts.Format)
+	}
+	return g.executeTemplate(w, "migration", r)
+}
+
+// outputExtension returns the file name extension for the output format.
+func outputExtension(format string) string {
+	switch format {
+	case "html":
+		return ".html"
+	case "markdown":
+		return ".md"
+	case "json":
+		return ".json"
+	case "yaml":
+		return ".yaml"
+	}
+	// 'jsonschema' writes a directory of files
+	return ""
+}
@@ -0,0 +1,140 @@
+package generators
+
+import (
+	"errors"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+// testIndex builds a type index with a type referenced by another one and a
+// default value for one of its fields.
+func testIndex(g *Generator, api, typeName, referencedBy, defaultValue string) *typeIndex {
+	idx := newTypeIndex(g, api)
+	p := &apiPackage{apiGroup: api + ".example.com", apiVersion: "v1"}
+	idx.typePkgMap["example.com/api."+typeName] = p
+	ref := testType(referencedBy)
+	ref.index = idx
+	idx.references["example.com/api.Shared"] = []*apiType{ref}
+	idx.defaultValues["example.com/api.Shared.Size"] = defaultValue
+	return idx
+}
+
+// referenceNames returns the sorted names of the types referencing a type.
+func referenceNames(idx *typeIndex, key string) []string {
+	var names []string
+	for _, t := range idx.references[key] {
+		names = append(names, t.Name.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func TestCopyFrom(t *testing.T) {
+	g := &Generator{}
+	other := testIndex(g, "first", "First", "FirstUser", "1")
+	user := other.references["example.com/api.Shared"][0]
+	other.references["example.com/api.Other"] = []*apiType{user}
+
+	idx := newTypeIndex(g, "second")
+	idx.copyFrom(other)
+
+	if !reflect.DeepEqual(idx.typePkgMap, other.typePkgMap) {
+		t.Errorf("Expected packages %v, got %v", other.typePkgMap, idx.typePkgMap)
+	}
+	if !reflect.DeepEqual(idx.defaultValues, other.defaultValues) {
+		t.Errorf("Expected default values %v, got %v", other.defaultValues, idx.defaultValues)
+	}
+	shared, otherRef := idx.references["example.com/api.Shared"][0], idx.references["example.com/api.Other"][0]
+	if shared.index != idx {
+		t.Errorf("Expected the referencing type to be rebound to the new index")
+	}
+	if shared != otherRef {
+		t.Errorf("Expected a type referencing several types to be rebound once")
+	}
+	if user.index != other {
+		t.Errorf("Expected the types of the other index to be unchanged")
+	}
+
+	idx.typePkgMap["example.com/api.Added"] = &apiPackage{}
+	idx.defaultValues["example.com/api.Shared.Size"] = "2"
+	idx.references["example.com/api.Shared"] = append(idx.references["example.com/api.Shared"], testType("Added"))
+	if _, ok := other.typePkgMap["example.com/api.Added"]; ok {
+		t.Errorf("Expected the packages of the other index to be unchanged")
+	}
+	if v := other.defaultValues["example.com/api.Shared.Size"]; v != "1" {
+		t.Errorf("Expected the default values of the other index to be unchanged, got %q", v)
+	}
+	if n := len(other.references["example.com/api.Shared"]); n != 1 {
+		t.Errorf("Expected the references of the other index to be unchanged, got %d", n)
+	}
+}
+
+func TestIndexAPIs(t *testing.T) {
+	tests := []struct {
+		Name      string
+		Migration bool
+		// Expected types and referencing types for each output
+		Types      [][]string
+		References [][]string
+	}{
+		{
+			Name: "in the order of the definitions",
+			Types: [][]string{
+				{"example.com/api.First"},
+				{"example.com/api.Failed"},
+				{"example.com/api.First", "example.com/api.Third"},
+			},
+			References: [][]string{
+				{"FirstUser"},
+				{"FailedUser"},
+				{"FirstUser", "ThirdUser"},
+			},
+		},
+		{
+			Name:      "all the definitions for migration",
+			Migration: true,
+			Types: [][]string{
+				{"example.com/api.First", "example.com/api.Third"},
+				{"example.com/api.Failed"},
+				{"example.com/api.First", "example.com/api.Third"},
+			},
+			References: [][]string{
+				{"FirstUser", "ThirdUser"},
+				{"FailedUser"},
+				{"FirstUser", "ThirdUser"},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			g := &Generator{opts: Options{Migration: test.Migration}}
+			outputs := []apiOutput{
+				{index: testIndex(g, "first", "First", "FirstUser", "1")},
+				{index: testIndex(g, "failed", "Failed", "FailedUser", "2"), err: errors.New("failed")},
+				{index: testIndex(g, "third", "Third", "ThirdUser", "3")},
+			}
+			g.indexAPIs(outputs)
+
+			for i, out := range outputs {
+				types := sortedKeys(out.index.typePkgMap)
+				if !reflect.DeepEqual(types, test.Types[i]) {
+					t.Errorf("Output %d: expected types %v, got %v", i, test.Types[i], types)
+				}
+				refs := referenceNames(out.index, "example.com/api.Shared")
+				if !reflect.DeepEqual(refs, test.References[i]) {
+					t.Errorf("Output %d: expected references %v, got %v", i, test.References[i], refs)
+				}
+				for _, ref := range out.index.references["example.com/api.Shared"] {
+					if !test.Migration && ref.index != out.index {
+						t.Errorf("Output %d: expected %s to be bound to the index of the output", i, ref.Name.Name)
+					}
+				}
+			}
+			// the default value of the first definition is kept
+			if v := outputs[2].index.defaultValues["example.com/api.Shared.Size"]; v != "1" {
+				t.Errorf("Expected the first default value, got %q", v)
+			}
+		})
+	}
+}
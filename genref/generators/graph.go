@@ -0,0 +1,146 @@
+package generators
+
+import (
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// typeGraph is the containment graph of the types in an API package. There is
+// an edge from each type to the local types of its fields, i.e. the reverse of
+// the 'references' map.
+type typeGraph struct {
+	name  string
+	nodes []graphNode
+	edges []*graphEdge
+}
+
+type graphNode struct {
+	id    string
+	label string
+}
+
+// graphEdge is labeled with the names of the fields using the target type.
+type graphEdge struct {
+	from   string
+	to     string
+	fields []string
+}
+
+var nonIdentPattern = regexp.MustCompile(`[^A-Za-z0-9_]+`)
+
+// graph builds the containment graph of the types documented in the package.
+func (p *apiPackage) graph() *typeGraph {
+	g := &typeGraph{name: p.DisplayName()}
+	seen := map[string]bool{}
+	addNode := func(t *apiType) string {
+		id := nonIdentPattern.ReplaceAllString(t.String(), "_")
+		if !seen[id] {
+			seen[id] = true
+			label := t.Name.Name
+			if p.index.typePkgMap[t.String()] != p {
+				// qualify the types of the other packages with their version
+				label = filepath.Base(t.Name.Package) + "." + label
+			}
+			g.nodes = append(g.nodes, graphNode{id: id, label: label})
+		}
+		return id
+	}
+
+	edges := map[string]*graphEdge{}
+	for _, t := range p.VisibleTypes() {
+		if !t.Referenced() && !t.IsExported() {
+			continue
+		}
+		from := addNode(t)
+		for _, m := range t.GetMembers() {
+			if m.Hidden() || reflect.StructTag(m.Tags).Get("json") == "-" {
+				continue
+			}
+			target := m.GetType()
+			for target.Elem != nil {
+				target = target.wrap(target.Elem)
+			}
+			if !target.isLocal() || target.isHidden() {
+				continue
+			}
+			to := addNode(target)
+			field := m.FieldName()
+			if m.IsInline() || (m.Embedded && reflect.StructTag(m.Tags).Get("json") == "") {
+				field = "(inline)"
+			}
+			key := from + " " + to
+			e, ok := edges[key]
+			if !ok {
+				e = &graphEdge{from: from, to: to}
+				edges[key] = e
+				g.edges = append(g.edges, e)
+			}
+			e.fields = append(e.fields, field)
+		}
+	}
+	return g
+}
+
+// dot returns the graph in the Graphviz DOT language.
+func (g *typeGraph) dot() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "digraph %s {\n", strconv.Quote(g.name))
+	b.WriteString("  rankdir=LR;\n")
+	b.WriteString("  node [shape=box];\n")
+	for _, n := range g.nodes {
+		fmt.Fprintf(&b, "  %s [label=%s];\n", n.id, strconv.Quote(n.label))
+	}
+	for _, e := range g.edges {
+		fmt.Fprintf(&b, "  %s -> %s [label=%s];\n", e.from, e.to, strconv.Quote(strings.Join(e.fields, ", ")))
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// mermaid returns the graph as a Mermaid flowchart.
+func (g *typeGraph) mermaid() string {
+	var b strings.Builder
+	b.WriteString("flowchart LR\n")
+	for _, n := range g.nodes {
+		fmt.Fprintf(&b, "  %s[\"%s\"]\n", n.id, n.label)
+	}
+	for _, e := range g.edges {
+		fmt.Fprintf(&b, "  %s -->|\"%s\"| %s\n", e.from, strings.Join(e.fields, ", "), e.to)
+	}
+	return b.String()
+}
+
+// Diagram returns the Mermaid diagram of the package when the diagrams are
+// embedded in the output. It returns an empty string otherwise, or if the
+// package has no type relationships to show.
+func (p *apiPackage) Diagram() string {
+	if !p.index.opts.EmbedGraphs || p.GroupName() == "" {
+		return ""
+	}
+	g := p.graph()
+	if len(g.edges) == 0 {
+		return ""
+	}
+	return g.mermaid()
+}
+
+// graphResults returns the DOT and Mermaid files of the graph of each API
+// package, written into the given directory.
+func graphResults(pkgs []*apiPackage, dir string) []Result {
+	var results []Result
+	for _, p := range pkgs {
+		if p.GroupName() == "" {
+			continue
+		}
+		g := p.graph()
+		results = append(results,
+			Result{Path: filepath.Join(dir, p.Anchor()+".dot"), Content: []byte(g.dot())},
+			Result{Path: filepath.Join(dir, p.Anchor()+".mmd"), Content: []byte(g.mermaid())},
+		)
+	}
+	return results
+}
@@ -0,0 +1,102 @@
+package generators
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/gengo/types"
+)
+
+// testGraphPackage returns a package with a kind using a local type twice,
+// an inline type, a type from another local package and an external type.
+func testGraphPackage() *apiPackage {
+	idx := newTypeIndex(&Generator{}, "test")
+	p := &apiPackage{apiGroup: "example.com", apiVersion: "v1", index: idx}
+	shared := &apiPackage{apiGroup: "shared.example.com", apiVersion: "v1", index: idx}
+
+	item := &types.Type{Name: types.Name{Package: "example.com/api", Name: "Item"}, Kind: types.Struct}
+	common := &types.Type{Name: types.Name{Package: "example.com/api", Name: "Common"}, Kind: types.Struct}
+	ref := &types.Type{Name: types.Name{Package: "example.com/shared/v1", Name: "Reference"}, Kind: types.Struct}
+	external := &types.Type{Name: types.Name{Package: "example.org/other", Name: "External"}, Kind: types.Struct}
+
+	kind := testType("Widget",
+		types.Member{Name: "Common", Type: common, Embedded: true, Tags: `json:",inline"`},
+		testMember("First", "first", item, false),
+		testMember("Rest", "rest", &types.Type{Kind: types.Slice, Elem: &types.Type{Kind: types.Pointer, Elem: item}}, true),
+		testMember("Owner", "owner", ref, true),
+		testMember("Other", "other", external, true),
+		types.Member{Name: "Internal", Type: item, Tags: `json:"-"`},
+	)
+	kind.index = idx
+	kind.CommentLines = []string{"+genclient"}
+	p.Types = []*apiType{kind, kind.wrap(item), kind.wrap(common)}
+
+	for _, t := range []*apiType{kind, kind.wrap(item), kind.wrap(common)} {
+		idx.typePkgMap[t.String()] = p
+	}
+	idx.typePkgMap[ref.String()] = shared
+	idx.references[item.String()] = []*apiType{kind}
+	idx.references[common.String()] = []*apiType{kind}
+	return p
+}
+
+func TestGraph(t *testing.T) {
+	g := testGraphPackage().graph()
+
+	dot := `digraph "example.com/v1" {
+  rankdir=LR;
+  node [shape=box];
+  example_com_api_Widget [label="Widget"];
+  example_com_api_Common [label="Common"];
+  example_com_api_Item [label="Item"];
+  example_com_shared_v1_Reference [label="v1.Reference"];
+  example_com_api_Widget -> example_com_api_Common [label="(inline)"];
+  example_com_api_Widget -> example_com_api_Item [label="first, rest"];
+  example_com_api_Widget -> example_com_shared_v1_Reference [label="owner"];
+}
+`
+	if actual := g.dot(); actual != dot {
+		t.Errorf("Expected DOT graph:\n%s\ngot:\n%s", dot, actual)
+	}
+
+	mermaid := `flowchart LR
+  example_com_api_Widget["Widget"]
+  example_com_api_Common["Common"]
+  example_com_api_Item["Item"]
+  example_com_shared_v1_Reference["v1.Reference"]
+  example_com_api_Widget -->|"(inline)"| example_com_api_Common
+  example_com_api_Widget -->|"first, rest"| example_com_api_Item
+  example_com_api_Widget -->|"owner"| example_com_shared_v1_Reference
+`
+	if actual := g.mermaid(); actual != mermaid {
+		t.Errorf("Expected Mermaid graph:\n%s\ngot:\n%s", mermaid, actual)
+	}
+}
+
+func TestDiagram(t *testing.T) {
+	p := testGraphPackage()
+	if d := p.Diagram(); d != "" {
+		t.Errorf("Expected no diagram unless the graphs are embedded, got:\n%s", d)
+	}
+	p.index.opts.EmbedGraphs = true
+	if d := p.Diagram(); !strings.HasPrefix(d, "flowchart LR\n") {
+		t.Errorf("Expected a Mermaid diagram, got:\n%s", d)
+	}
+	p.Types = p.Types[2:]
+	if d := p.Diagram(); d != "" {
+		t.Errorf("Expected no diagram for a package without relationships, got:\n%s", d)
+	}
+}
+
+func TestGraphResults(t *testing.T) {
+	core := &apiPackage{apiVersion: "v1", index: newTypeIndex(&Generator{}, "test")}
+	results := graphResults([]*apiPackage{testGraphPackage(), core}, "graphs")
+	var paths []string
+	for _, r := range results {
+		paths = append(paths, r.Path)
+	}
+	expected := "graphs/example-com-v1.dot graphs/example-com-v1.mmd"
+	if actual := strings.Join(paths, " "); actual != expected {
+		t.Errorf("Expected results %q, got %q", expected, actual)
+	}
+}
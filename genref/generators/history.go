@@ -0,0 +1,260 @@
+package generators
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"k8s.io/klog/v2"
+)
+
+// history holds the releases where each type and field appeared and was
+// removed, keyed by PackagePath.Type and PackagePath.Type.Field.
+type history struct {
+	entries map[string]*historyEntry
+}
+
+// historyEntry is the first release where a type or a field is found and the
+// release where it is removed, if any. Added is empty if it is found in the
+// oldest release, because it may have been added before.
+type historyEntry struct {
+	Added   string
+	Removed string
+}
+
+// loadHistory parses the packages of the API definitions in the source root
+// of each release. It returns nil if no release is specified.
+func loadHistory(releases []Release, defs []APIDefinition) (*history, error) {
+	if len(releases) == 0 {
+		return nil, nil
+	}
+	sorted, err := sortReleases(releases)
+	if err != nil {
+		return nil, err
+	}
+
+	var pkgs []string
+	for _, item := range defs {
+		for _, p := range append([]string{apiDir(item)}, item.Includes...) {
+			if !containsString(pkgs, p) {
+				pkgs = append(pkgs, p)
+			}
+		}
+	}
+
+	// the names found in each release, in order
+	found := make([]map[string]bool, len(sorted))
+	for i, r := range sorted {
+		found[i] = make(map[string]bool)
+		for _, pkg := range pkgs {
+			dir := releaseDir(r.Path, pkg)
+			if dir == "" {
+				klog.Warningf("Package %s is not found in the source root %s of release %s", pkg, r.Path, r.Version)
+				continue
+			}
+			if err := collectNames(dir, pkg, found[i]); err != nil {
+				return nil, fmt.Errorf("failed to parse package %s of release %s: %w", pkg, r.Version, err)
+			}
+		}
+	}
+
+	h := &history{entries: make(map[string]*historyEntry)}
+	for i, r := range sorted {
+		for name := range found[i] {
+			if _, ok := h.entries[name]; ok {
+				continue
+			}
+			e := &historyEntry{}
+			if i > 0 {
+				e.Added = r.Version
+			}
+			h.entries[name] = e
+		}
+	}
+	for name, e := range h.entries {
+		last := -1
+		for i := range sorted {
+			if found[i][name] {
+				last = i
+			}
+		}
+		if last < len(sorted)-1 {
+			e.Removed = sorted[last+1].Version
+		}
+	}
+	return h, nil
+}
+
+// lookup returns the history of a type or a field. It returns nil if the
+// history is not loaded or if the name is not found in any release.
+func (h *history) lookup(name string) *historyEntry {
+	if h == nil {
+		return nil
+	}
+	return h.entries[name]
+}
+
+// sortReleases returns the releases sorted from the oldest one.
+func sortReleases(releases []Release) ([]Release, error) {
+	type key struct{ major, minor, patch int }
+	keys := map[string]key{}
+	for _, r := range releases {
+		m := kubernetesVersionPattern.FindStringSubmatch(r.Version)
+		if m == nil {
+			return nil, fmt.Errorf("invalid release version %q, expecting a version like 'v1.35'", r.Version)
+		}
+		if r.Path == "" {
+			return nil, fmt.Errorf("no source root specified for release %s", r.Version)
+		}
+		var k key
+		k.major, _ = strconv.Atoi(m[1])
+		k.minor, _ = strconv.Atoi(m[2])
+		k.patch, _ = strconv.Atoi(strings.TrimPrefix(m[3], "."))
+		keys[r.Version] = k
+	}
+
+	sorted := append([]Release(nil), releases...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := keys[sorted[i].Version], keys[sorted[j].Version]
+		if a.major != b.major {
+			return a.major < b.major
+		}
+		if a.minor != b.minor {
+			return a.minor < b.minor
+		}
+		return a.patch < b.patch
+	})
+	return sorted, nil
+}
+
+// releaseDir returns the directory of a package in the source root of a
+// release, or an empty string if it is not found. The root is either a
+// kubernetes/kubernetes checkout, where the packages of the other modules are
+// found in 'staging/src', or a directory with the packages at
+// <root>/<import path>.
+func releaseDir(root, pkg string) string {
+	candidates := []string{
+		filepath.Join(root, pkg),
+		filepath.Join(root, "staging", "src", pkg),
+	}
+	if rest, ok := strings.CutPrefix(pkg, "k8s.io/kubernetes/"); ok {
+		candidates = append(candidates, filepath.Join(root, rest))
+	}
+	for _, dir := range candidates {
+		if fi, err := os.Stat(dir); err == nil && fi.IsDir() {
+			return dir
+		}
+	}
+	return ""
+}
+
+// collectNames adds the names of the types and struct fields declared in a
+// package directory, in the format of PackagePath.Type and
+// PackagePath.Type.Field.
+func collectNames(dir, pkg string, names map[string]bool) error {
+	fset := token.NewFileSet()
+	notTest := func(fi fs.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go")
+	}
+	parsed, err := parser.ParseDir(fset, dir, notTest, parser.SkipObjectResolution)
+	if err != nil {
+		return err
+	}
+	for _, p := range parsed {
+		for _, f := range p.Files {
+			for _, decl := range f.Decls {
+				gd, ok := decl.(*ast.GenDecl)
+				if !ok || gd.Tok != token.TYPE {
+					continue
+				}
+				for _, spec := range gd.Specs {
+					ts := spec.(*ast.TypeSpec)
+					typeName := pkg + "." + ts.Name.Name
+					names[typeName] = true
+					st, ok := ts.Type.(*ast.StructType)
+					if !ok {
+						continue
+					}
+					for _, field := range st.Fields.List {
+						for _, n := range fieldNames(field) {
+							names[typeName+"."+n] = true
+						}
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// fieldNames returns the names of a struct field declaration. The name of an
+// embedded field is its type name.
+func fieldNames(field *ast.Field) []string {
+	if len(field.Names) > 0 {
+		var names []string
+		for _, n := range field.Names {
+			names = append(names, n.Name)
+		}
+		return names
+	}
+	expr := field.Type
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+	switch x := expr.(type) {
+	case *ast.Ident:
+		return []string{x.Name}
+	case *ast.SelectorExpr:
+		return []string{x.Sel.Name}
+	}
+	return nil
+}
+
+// AddedIn returns the release where the type was added, if known.
+func (t *apiType) AddedIn() string {
+	if e := t.index.history.lookup(t.Name.String()); e != nil {
+		return e.Added
+	}
+	return ""
+}
+
+// RemovedIn returns the release where the type was removed, if any.
+func (t *apiType) RemovedIn() string {
+	if e := t.index.history.lookup(t.Name.String()); e != nil {
+		return e.Removed
+	}
+	return ""
+}
+
+// AddedIn returns the release where the field was added, if known and if it is
+// not the release where its type was added.
+func (m *apiMember) AddedIn() string {
+	e := m.history()
+	if e == nil || e.Added == m.parent.AddedIn() {
+		// the fields of a new type are not annotated
+		return ""
+	}
+	return e.Added
+}
+
+// RemovedIn returns the release where the field was removed, if any.
+func (m *apiMember) RemovedIn() string {
+	if e := m.history(); e != nil {
+		return e.Removed
+	}
+	return ""
+}
+
+func (m *apiMember) history() *historyEntry {
+	if m.parent == nil {
+		return nil
+	}
+	return m.index.history.lookup(m.parent.Name.String() + "." + m.Name)
+}
@@ -0,0 +1,102 @@
+package generators
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// writeRelease writes a source root with the given source for the package
+// example.com/api, under 'staging/src' if staging is set.
+func writeRelease(t *testing.T, source string, staging bool) string {
+	root := t.TempDir()
+	dir := filepath.Join(root, "example.com", "api")
+	if staging {
+		dir = filepath.Join(root, "staging", "src", "example.com", "api")
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "types.go"), []byte(source), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return root
+}
+
+func TestLoadHistory(t *testing.T) {
+	releases := []Release{
+		{Version: "v1.32", Path: writeRelease(t, `package api
+type Config struct {
+	Address string
+	*Extra
+}
+type Extra struct{}
+`, false)},
+		{Version: "v1.30", Path: writeRelease(t, `package api
+type Config struct {
+	Port, Timeout int32
+}
+`, true)},
+		{Version: "v1.31", Path: writeRelease(t, `package api
+type Config struct {
+	Port    int32
+	Address string
+}
+`, false)},
+	}
+	defs := []APIDefinition{{Name: "test", Package: "example.com", Path: "api"}}
+
+	h, err := loadHistory(releases, defs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		Name     string
+		Expected *historyEntry
+	}{
+		{"example.com/api.Config", &historyEntry{}},
+		{"example.com/api.Config.Port", &historyEntry{Removed: "v1.32"}},
+		{"example.com/api.Config.Timeout", &historyEntry{Removed: "v1.31"}},
+		{"example.com/api.Config.Address", &historyEntry{Added: "v1.31"}},
+		{"example.com/api.Config.Extra", &historyEntry{Added: "v1.32"}},
+		{"example.com/api.Extra", &historyEntry{Added: "v1.32"}},
+		{"example.com/api.Missing", nil},
+	}
+	for _, test := range tests {
+		if result := h.lookup(test.Name); !reflect.DeepEqual(result, test.Expected) {
+			t.Errorf("%s: expected %+v, got %+v", test.Name, test.Expected, result)
+		}
+	}
+
+	if h, err := loadHistory(nil, defs); h != nil || err != nil {
+		t.Errorf("Expected no history without releases, got %v (%v)", h, err)
+	}
+}
+
+func TestSortReleases(t *testing.T) {
+	releases := []Release{
+		{Version: "v1.9", Path: "a"},
+		{Version: "1.30.2", Path: "b"},
+		{Version: "v1.30", Path: "c"},
+		{Version: "v1.10", Path: "d"},
+	}
+	sorted, err := sortReleases(releases)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var versions []string
+	for _, r := range sorted {
+		versions = append(versions, r.Version)
+	}
+	expected := []string{"v1.9", "v1.10", "v1.30", "1.30.2"}
+	if !reflect.DeepEqual(versions, expected) {
+		t.Errorf("Expected %v, got %v", expected, versions)
+	}
+
+	for _, r := range []Release{{Version: "latest", Path: "a"}, {Version: "v1.30"}} {
+		if _, err := sortReleases([]Release{r}); err == nil {
+			t.Errorf("Expected an error for %+v", r)
+		}
+	}
+}
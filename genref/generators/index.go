@@ -0,0 +1,106 @@
+package generators
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+)
+
+// indexEntry lists the generated versions of an API in the index page.
+type indexEntry struct {
+	// Name is the name of the API definitions
+	Name string
+	// Title is the title of the API without the version
+	Title string
+	// Versions are sorted from the most recent one
+	Versions []indexVersion
+}
+
+// indexVersion is a generated version of an API.
+type indexVersion struct {
+	Version string
+	// Stability is one of 'alpha', 'beta' and 'GA', or empty if the version
+	// cannot be parsed.
+	Stability string
+	// Title is the title of the page
+	Title string
+	// Link is the path of the page relative to the index page
+	Link string
+}
+
+// stability returns the stability level of a Kubernetes API version.
+func stability(version string) string {
+	m := versionPattern.FindStringSubmatch(version)
+	if m == nil {
+		return ""
+	}
+	if m[2] == "" {
+		return "GA"
+	}
+	return m[2]
+}
+
+// indexPath returns the file name of the index page for the output format.
+// For markdown, it is the list page of a Hugo section.
+func indexPath(format string) string {
+	if format == "markdown" {
+		return "_index.md"
+	}
+	return "index" + outputExtension(format)
+}
+
+// buildIndex groups the processed API definitions by name, in the order of
+// their first appearance in the configuration. A version rendered more than
+// once is listed once, for the page written last.
+func (g *Generator) buildIndex(apis []processedAPI) []*indexEntry {
+	var entries []*indexEntry
+	byName := map[string]*indexEntry{}
+	for _, api := range apis {
+		e, ok := byName[api.Definition.Name]
+		if !ok {
+			e = &indexEntry{Name: api.Definition.Name}
+			byName[e.Name] = e
+			entries = append(entries, e)
+		}
+		v := indexVersion{
+			Version:   api.Version,
+			Stability: stability(api.Version),
+			Title:     api.Definition.Title,
+			Link:      fmt.Sprintf("%s.%s%s", api.Definition.Name, api.Version, outputExtension(g.opts.Format)),
+		}
+		replaced := false
+		for i := range e.Versions {
+			if e.Versions[i].Version == v.Version {
+				e.Versions[i] = v
+				replaced = true
+			}
+		}
+		if !replaced {
+			e.Versions = append(e.Versions, v)
+		}
+	}
+
+	for _, e := range entries {
+		sort.SliceStable(e.Versions, func(i, j int) bool {
+			return compareVersions(e.Versions[i].Version, e.Versions[j].Version) > 0
+		})
+		e.Title = titleVersionPattern.ReplaceAllString(e.Versions[0].Title, "")
+	}
+	return entries
+}
+
+// renderIndex renders the index page for the processed API definitions.
+func (g *Generator) renderIndex(apis []processedAPI) (Result, error) {
+	var b bytes.Buffer
+	params := map[string]interface{}{
+		"apis":      g.buildIndex(apis),
+		"gitCommit": g.gitCommit,
+	}
+	if err := g.executeTemplate(&b, "index", params); err != nil {
+		return Result{}, err
+	}
+	return Result{
+		Path:    fmt.Sprintf("%s/%s", g.opts.OutputPath, indexPath(g.opts.Format)),
+		Content: b.Bytes(),
+	}, nil
+}
@@ -0,0 +1,132 @@
+package generators
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// testProcessedAPIs returns the processed API definitions of two APIs, one
+// of them rendered twice for the same version.
+func testProcessedAPIs() []processedAPI {
+	api := func(name, title, version string) processedAPI {
+		return processedAPI{Definition: APIDefinition{Name: name, Title: title}, Version: version}
+	}
+	return []processedAPI{
+		api("kubelet-config", "Kubelet Configuration (v1beta1)", "v1beta1"),
+		api("kubeadm-config", "kubeadm Configuration (v1beta3)", "v1beta3"),
+		api("kubelet-config", "Kubelet Configuration (v1)", "v1"),
+		api("kubelet-config", "Kubelet Configuration (v1alpha1)", "v1alpha1"),
+		api("kubeadm-config", "kubeadm Configuration (v1beta4)", "v1beta4"),
+		api("kubeadm-config", "kubeadm Config (v1beta3)", "v1beta3"),
+	}
+}
+
+func TestStability(t *testing.T) {
+	tests := map[string]string{
+		"v1":       "GA",
+		"v2":       "GA",
+		"v1beta1":  "beta",
+		"v1alpha3": "alpha",
+		"v1beta":   "",
+		"latest":   "",
+	}
+	for version, expected := range tests {
+		if actual := stability(version); actual != expected {
+			t.Errorf("stability(%q): expected %q, got %q", version, expected, actual)
+		}
+	}
+}
+
+func TestIndexPath(t *testing.T) {
+	tests := map[string]string{
+		"markdown": "_index.md",
+		"html":     "index.html",
+	}
+	for format, expected := range tests {
+		if actual := indexPath(format); actual != expected {
+			t.Errorf("indexPath(%q): expected %q, got %q", format, expected, actual)
+		}
+	}
+}
+
+func TestBuildIndex(t *testing.T) {
+	g := &Generator{opts: Options{Format: "markdown"}}
+	entries := g.buildIndex(testProcessedAPIs())
+
+	var actual []indexEntry
+	for _, e := range entries {
+		actual = append(actual, *e)
+	}
+	expected := []indexEntry{
+		{
+			Name:  "kubelet-config",
+			Title: "Kubelet Configuration",
+			Versions: []indexVersion{
+				{Version: "v1", Stability: "GA", Title: "Kubelet Configuration (v1)", Link: "kubelet-config.v1.md"},
+				{Version: "v1beta1", Stability: "beta", Title: "Kubelet Configuration (v1beta1)", Link: "kubelet-config.v1beta1.md"},
+				{Version: "v1alpha1", Stability: "alpha", Title: "Kubelet Configuration (v1alpha1)", Link: "kubelet-config.v1alpha1.md"},
+			},
+		},
+		{
+			Name:  "kubeadm-config",
+			Title: "kubeadm Configuration",
+			Versions: []indexVersion{
+				{Version: "v1beta4", Stability: "beta", Title: "kubeadm Configuration (v1beta4)", Link: "kubeadm-config.v1beta4.md"},
+				// the page written last is listed
+				{Version: "v1beta3", Stability: "beta", Title: "kubeadm Config (v1beta3)", Link: "kubeadm-config.v1beta3.md"},
+			},
+		},
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Expected index %+v, got %+v", expected, actual)
+	}
+}
+
+func TestRenderIndex(t *testing.T) {
+	tests := []struct {
+		Format   string
+		Path     string
+		Expected []string
+	}{
+		{
+			Format: "markdown",
+			Path:   "out/_index.md",
+			Expected: []string{
+				"## Kubelet Configuration\n",
+				"- [`v1`](kubelet-config.v1.md) (GA)\n",
+				"- [`v1alpha1`](kubelet-config.v1alpha1.md) (alpha)\n",
+				"## kubeadm Configuration\n",
+			},
+		},
+		{
+			Format: "html",
+			Path:   "out/index.html",
+			Expected: []string{
+				"Kubelet Configuration",
+				`href="kubelet-config.v1beta1.html"`,
+				`href="kubeadm-config.v1beta4.html"`,
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Format, func(t *testing.T) {
+			g, err := New(&Config{}, Options{Format: test.Format, TemplateDir: "..", OutputPath: "out"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			r, err := g.renderIndex(testProcessedAPIs())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if r.Path != test.Path {
+				t.Errorf("Expected path %q, got %q", test.Path, r.Path)
+			}
+			for _, s := range test.Expected {
+				if !strings.Contains(string(r.Content), s) {
+					t.Errorf("Expected %q in:\n%s", s, r.Content)
+				}
+			}
+		})
+	}
+}
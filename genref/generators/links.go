@@ -0,0 +1,173 @@
+package generators
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	texttemplate "text/template"
+
+	"k8s.io/klog/v2"
+)
+
+// Kinds of link issues
+const (
+	// IssueUnresolved is for a type that matches no external package rule.
+	IssueUnresolved = "unresolved"
+	// IssuePattern is for a rule whose 'match' pattern fails to compile.
+	IssuePattern = "pattern"
+	// IssueTemplate is for a rule whose 'target' template fails to parse or
+	// to execute.
+	IssueTemplate = "template"
+)
+
+// LinkIssue is a problem found when building the link to an external type.
+type LinkIssue struct {
+	// Kind is one of 'unresolved', 'pattern' and 'template'.
+	Kind string
+
+	// Type is the type identifier in the format of PackagePath.Name. It is
+	// empty for an invalid rule.
+	Type string
+
+	// Rule is the 'match' pattern of the external package rule involved.
+	Rule string
+
+	// APIs lists the names of the API definitions where the issue is found.
+	APIs []string
+
+	// Details is the error message, if any.
+	Details string
+}
+
+// linkRule is an external package rule with its pattern and template
+// compiled.
+type linkRule struct {
+	ExternalPackage
+
+	match    *regexp.Regexp
+	matchErr error
+
+	target    *texttemplate.Template
+	targetErr error
+}
+
+// linkFuncs are the functions available to the 'target' templates.
+var linkFuncs = texttemplate.FuncMap{
+	"lower":    strings.ToLower,
+	"arrIndex": arrIndex,
+}
+
+func arrIndex(a []string, i int) string {
+	s := a[(len(a)+i)%len(a)]
+	if s == "authentication" {
+		s = "authentication-k8s-io"
+	}
+	return s
+}
+
+// kubernetesVersionPattern matches a Kubernetes release like 'v1.35' or
+// '1.35.2'.
+var kubernetesVersionPattern = regexp.MustCompile(`^v?(\d+)\.(\d+)(\.\d+)?(-[0-9A-Za-z.-]+)?$`)
+
+// linkVars returns the variables for the 'target' templates. The Kubernetes
+// release of the options takes precedence over the one in the configuration.
+// The release variables are not set if no release is specified, so that the
+// templates using them fail.
+func linkVars(cfg *Config, kubernetesVersion string) (map[string]interface{}, error) {
+	vars := cfg.Vars
+	if vars == nil {
+		vars = map[string]string{}
+	}
+	result := map[string]interface{}{"Vars": vars}
+
+	if kubernetesVersion == "" {
+		kubernetesVersion = cfg.KubernetesVersion
+	}
+	if kubernetesVersion == "" {
+		return result, nil
+	}
+	m := kubernetesVersionPattern.FindStringSubmatch(kubernetesVersion)
+	if m == nil {
+		return nil, fmt.Errorf("invalid Kubernetes version %q, expecting a version like 'v1.35'", kubernetesVersion)
+	}
+	result["KubernetesVersion"] = "v" + strings.TrimPrefix(kubernetesVersion, "v")
+	result["KubernetesMinor"] = m[1] + "." + m[2]
+	return result, nil
+}
+
+// compileLinkRules compiles the external package rules in the configuration.
+func compileLinkRules(pkgs []ExternalPackage) []*linkRule {
+	var rules []*linkRule
+	for _, v := range pkgs {
+		r := &linkRule{ExternalPackage: v}
+		r.match, r.matchErr = regexp.Compile(v.Match)
+		if r.matchErr != nil {
+			klog.Errorf("Pattern %q failed to compile: %+v", v.Match, r.matchErr)
+		}
+		// a variable that is not set, e.g. the Kubernetes release, is an error
+		r.target, r.targetErr = texttemplate.New("").Option("missingkey=error").Funcs(linkFuncs).Parse(v.Target)
+		if r.targetErr != nil {
+			klog.Errorf("Failed to parse the 'target': %s", v.Target)
+		}
+		rules = append(rules, r)
+	}
+	return rules
+}
+
+// linkReport collects the link issues without duplicates. It is safe for
+// concurrent use.
+type linkReport struct {
+	mu     sync.Mutex
+	issues map[string]*LinkIssue
+}
+
+// newLinkReport creates a report with the issues of the invalid rules.
+func newLinkReport(rules []*linkRule) *linkReport {
+	r := &linkReport{issues: make(map[string]*LinkIssue)}
+	for _, rule := range rules {
+		if rule.matchErr != nil {
+			r.add(IssuePattern, "", rule.Match, "", rule.matchErr.Error())
+		}
+		if rule.targetErr != nil {
+			r.add(IssueTemplate, "", rule.Match, "", rule.targetErr.Error())
+		}
+	}
+	return r
+}
+
+// add records an issue found for an API definition.
+func (r *linkReport) add(kind, typeName, rule, api, details string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := strings.Join([]string{kind, typeName, rule}, "|")
+	issue, ok := r.issues[key]
+	if !ok {
+		issue = &LinkIssue{Kind: kind, Type: typeName, Rule: rule, Details: details}
+		r.issues[key] = issue
+	}
+	if api != "" && !containsString(issue.APIs, api) {
+		issue.APIs = append(issue.APIs, api)
+		sort.Strings(issue.APIs)
+	}
+}
+
+// list returns the issues sorted by kind, type and rule.
+func (r *linkReport) list() []LinkIssue {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	result := make([]LinkIssue, 0, len(r.issues))
+	for _, key := range sortedKeys(r.issues) {
+		result = append(result, *r.issues[key])
+	}
+	return result
+}
+
+// LinkIssues returns the link issues found by the last run of Generate,
+// together with the invalid external package rules in the configuration.
+func (g *Generator) LinkIssues() []LinkIssue {
+	return g.links.list()
+}
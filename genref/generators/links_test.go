@@ -0,0 +1,150 @@
+package generators
+
+import (
+	"testing"
+
+	"k8s.io/gengo/types"
+)
+
+func TestLink(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Rules    []ExternalPackage
+		Expected string
+	}{
+		{
+			Name: "matching rule",
+			Rules: []ExternalPackage{
+				{Match: `^k8s\.io/api/core/v1\.`, Target: "https://example.com/{{ .TypeIdentifier }}"},
+			},
+			Expected: "https://example.com/Container",
+		},
+		{
+			Name: "invalid pattern is skipped",
+			Rules: []ExternalPackage{
+				{Match: `^k8s\.io/(api`, Target: "https://invalid.example.com/"},
+				{Match: `^k8s\.io/api/`, Target: "https://example.com/{{ arrIndex .PackageSegments -2 }}"},
+			},
+			Expected: "https://example.com/core",
+		},
+		{
+			Name: "invalid target of the matching rule",
+			Rules: []ExternalPackage{
+				{Match: `^k8s\.io/api/`, Target: "https://example.com/{{ .TypeIdentifier"},
+				{Match: `.*`, Target: "https://other.example.com/"},
+			},
+			Expected: "",
+		},
+		{
+			Name:     "no matching rule",
+			Rules:    []ExternalPackage{{Match: `^example\.com/`, Target: "https://example.com/"}},
+			Expected: "",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			g := &Generator{linkRules: compileLinkRules(test.Rules)}
+			g.links = newLinkReport(g.linkRules)
+			typ := &apiType{
+				Type: types.Type{
+					Name: types.Name{Package: "k8s.io/api/core/v1", Name: "Container"},
+					Kind: types.Struct,
+				},
+				index: newTypeIndex(g, "test"),
+			}
+			if result := typ.Link(); result != test.Expected {
+				t.Errorf("Expected %q, got %q", test.Expected, result)
+			}
+		})
+	}
+}
+
+func TestLinkVars(t *testing.T) {
+	tests := []struct {
+		Name          string
+		Config        Config
+		Version       string
+		Error         bool
+		ExpectVersion string
+		ExpectMinor   string
+	}{
+		{Name: "no release"},
+		{
+			Name:          "release of the options",
+			Config:        Config{KubernetesVersion: "v1.34"},
+			Version:       "1.35.2",
+			ExpectVersion: "v1.35.2",
+			ExpectMinor:   "1.35",
+		},
+		{
+			Name:          "release of the configuration",
+			Config:        Config{KubernetesVersion: "v1.36.0-alpha.1"},
+			ExpectVersion: "v1.36.0-alpha.1",
+			ExpectMinor:   "1.36",
+		},
+		{Name: "invalid release", Version: "latest", Error: true},
+		{Name: "missing minor version", Version: "v1", Error: true},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			vars, err := linkVars(&test.Config, test.Version)
+			if test.Error {
+				if err == nil {
+					t.Errorf("Expected an error, got %v", vars)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, ok := vars["Vars"]; !ok {
+				t.Errorf("Expected the configuration variables to be set")
+			}
+			for key, expected := range map[string]string{"KubernetesVersion": test.ExpectVersion, "KubernetesMinor": test.ExpectMinor} {
+				v, ok := vars[key]
+				if expected == "" {
+					if ok {
+						t.Errorf("Expected %s not to be set, got %v", key, v)
+					}
+				} else if v != expected {
+					t.Errorf("Expected %s %q, got %v", key, expected, v)
+				}
+			}
+		})
+	}
+}
+
+func TestLinkKubernetesRelease(t *testing.T) {
+	rules := []ExternalPackage{
+		{Match: `^k8s\.io/api/`, Target: "https://kubernetes.io/docs/reference/generated/kubernetes-api/v{{ .KubernetesMinor }}/#{{ lower .TypeIdentifier }}"},
+	}
+	tests := []struct {
+		Name     string
+		Version  string
+		Expected string
+	}{
+		{Name: "release set", Version: "v1.35", Expected: "https://kubernetes.io/docs/reference/generated/kubernetes-api/v1.35/#container"},
+		{Name: "release not set", Expected: ""},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			vars, err := linkVars(&Config{}, test.Version)
+			if err != nil {
+				t.Fatal(err)
+			}
+			g := &Generator{linkRules: compileLinkRules(rules), linkVars: vars}
+			g.links = newLinkReport(g.linkRules)
+			typ := &apiType{
+				Type: types.Type{
+					Name: types.Name{Package: "k8s.io/api/core/v1", Name: "Container"},
+					Kind: types.Struct,
+				},
+				index: newTypeIndex(g, "test"),
+			}
+			if result := typ.Link(); result != test.Expected {
+				t.Errorf("Expected %q, got %q", test.Expected, result)
+			}
+		})
+	}
+}
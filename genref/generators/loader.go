@@ -0,0 +1,214 @@
+package generators
+
+import (
+	"errors"
+	"fmt"
+	"go/build"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"unsafe"
+
+	"golang.org/x/mod/modfile"
+	"golang.org/x/tools/go/packages"
+	"k8s.io/gengo/parser"
+	"k8s.io/klog/v2"
+)
+
+// moduleLoader resolves Go packages through the go.mod file of the current
+// module, including its replace directives. The modules of the API
+// definitions with a local checkout are replaced by the checkout, using a
+// copy of the go.mod file with extra replace directives. The go command is
+// run in the directory of the copy, both for resolving the packages and for
+// parsing them with gengo, so that the process environment is not modified.
+type moduleLoader struct {
+	// tmpDir holds the copy of the go.mod and go.sum files, if any
+	tmpDir string
+
+	mu sync.Mutex
+	// dirs caches the source directory of each package, by import path
+	dirs map[string]string
+}
+
+// newModuleLoader creates a loader for the API definitions.
+func newModuleLoader(defs []APIDefinition) (*moduleLoader, error) {
+	l := &moduleLoader{dirs: make(map[string]string)}
+
+	replaces := map[string]string{}
+	for _, item := range defs {
+		if item.LocalPath == "" {
+			continue
+		}
+		dir, err := filepath.Abs(item.LocalPath)
+		if err != nil {
+			return nil, fmt.Errorf("invalid localPath %q for API %s: %w", item.LocalPath, item.Name, err)
+		}
+		data, err := os.ReadFile(filepath.Join(dir, "go.mod"))
+		if err != nil {
+			return nil, fmt.Errorf("localPath %q for API %s is not a Go module checkout: %w", item.LocalPath, item.Name, err)
+		}
+		mod := modfile.ModulePath(data)
+		if mod == "" {
+			return nil, fmt.Errorf("no module path found in %s", filepath.Join(dir, "go.mod"))
+		}
+		if old, ok := replaces[mod]; ok && old != dir {
+			return nil, fmt.Errorf("conflicting local checkouts for module %s: %s and %s", mod, old, dir)
+		}
+		replaces[mod] = dir
+	}
+	if len(replaces) == 0 {
+		return l, nil
+	}
+
+	if err := l.writeModFile(replaces); err != nil {
+		l.close()
+		return nil, err
+	}
+	return l, nil
+}
+
+// writeModFile writes a copy of the go.mod file of the current module with
+// the modules replaced by the local checkouts.
+func (l *moduleLoader) writeModFile(replaces map[string]string) error {
+	out, err := exec.Command("go", "env", "GOMOD").Output()
+	if err != nil {
+		return fmt.Errorf("failed to find the go.mod file: %w", err)
+	}
+	gomod := strings.TrimSpace(string(out))
+	if gomod == "" || gomod == os.DevNull {
+		return errors.New("local checkouts require a go.mod file in the current directory")
+	}
+
+	data, err := os.ReadFile(gomod)
+	if err != nil {
+		return err
+	}
+	f, err := modfile.Parse(gomod, data, nil)
+	if err != nil {
+		return fmt.Errorf("failed to parse %s: %w", gomod, err)
+	}
+	// the replaced directories are relative to the original go.mod file
+	for _, r := range f.Replace {
+		if r.New.Version == "" && modfile.IsDirectoryPath(r.New.Path) && !filepath.IsAbs(r.New.Path) {
+			dir := filepath.Join(filepath.Dir(gomod), r.New.Path)
+			if err := f.AddReplace(r.Old.Path, r.Old.Version, dir, ""); err != nil {
+				return fmt.Errorf("failed to replace module %s: %w", r.Old.Path, err)
+			}
+		}
+	}
+	for _, mod := range sortedKeys(replaces) {
+		klog.V(0).Infof("Using local checkout %s for module %s", replaces[mod], mod)
+		if err := f.AddReplace(mod, "", replaces[mod], ""); err != nil {
+			return fmt.Errorf("failed to replace module %s: %w", mod, err)
+		}
+	}
+	data, err = f.Format()
+	if err != nil {
+		return err
+	}
+
+	if l.tmpDir, err = os.MkdirTemp("", "genref"); err != nil {
+		return err
+	}
+	tmpMod := filepath.Join(l.tmpDir, "go.mod")
+	if err := os.WriteFile(tmpMod, data, 0644); err != nil {
+		return err
+	}
+	// the go.sum file is expected next to the go.mod file
+	sum, err := os.ReadFile(strings.TrimSuffix(gomod, ".mod") + ".sum")
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return os.WriteFile(filepath.Join(l.tmpDir, "go.sum"), sum, 0644)
+}
+
+// close removes the temporary files of the loader.
+func (l *moduleLoader) close() {
+	if l.tmpDir != "" {
+		os.RemoveAll(l.tmpDir)
+	}
+}
+
+// resolve looks up the packages with the given import paths. It returns an
+// error for each package that cannot be resolved.
+func (l *moduleLoader) resolve(paths []string) (map[string]error, error) {
+	cfg := &packages.Config{
+		Mode: packages.NeedName | packages.NeedFiles | packages.NeedModule,
+		Dir:  l.tmpDir,
+	}
+	pkgs, err := packages.Load(cfg, paths...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load packages: %w", err)
+	}
+
+	errs := make(map[string]error)
+	found := make(map[string]bool)
+	for _, p := range pkgs {
+		found[p.PkgPath] = true
+		if len(p.Errors) > 0 {
+			var msgs []string
+			for _, e := range p.Errors {
+				msgs = append(msgs, e.Msg)
+			}
+			errs[p.PkgPath] = fmt.Errorf("cannot resolve package %s: %s (make sure its module is required in go.mod, or set 'localPath' for the API definition)",
+				p.PkgPath, strings.Join(msgs, "; "))
+			continue
+		}
+		if p.Module != nil {
+			klog.V(4).Infof("Package %s is resolved to module %s at %s", p.PkgPath, p.Module.Path, p.Dir)
+		}
+		l.mu.Lock()
+		l.dirs[p.PkgPath] = p.Dir
+		l.mu.Unlock()
+	}
+	for _, path := range paths {
+		if !found[path] {
+			errs[path] = fmt.Errorf("cannot resolve package %s", path)
+		}
+	}
+	return errs, nil
+}
+
+// dir returns the source directory of a package.
+func (l *moduleLoader) dir(path string) (string, error) {
+	l.mu.Lock()
+	dir, ok := l.dirs[path]
+	l.mu.Unlock()
+	if ok {
+		return dir, nil
+	}
+
+	errs, err := l.resolve([]string{path})
+	if err != nil {
+		return "", err
+	}
+	if err := errs[path]; err != nil {
+		return "", err
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.dirs[path], nil
+}
+
+// newBuilder creates a gengo builder that runs the go command in the same
+// directory as the loader. gengo copies the default build context when the
+// builder is created and does not expose the copy, so the directory is set on
+// the copy through reflection. The default build context itself is shared by
+// the generators running concurrently and is never modified.
+func (l *moduleLoader) newBuilder() (*parser.Builder, error) {
+	b := parser.New()
+	if l.tmpDir == "" {
+		return b, nil
+	}
+	f := reflect.ValueOf(b).Elem().FieldByName("context")
+	if !f.IsValid() || f.Type() != reflect.TypeOf(&build.Context{}) {
+		return nil, errors.New("cannot set the directory of the gengo build context")
+	}
+	ctx := reflect.NewAt(f.Type(), unsafe.Pointer(f.UnsafeAddr())).Elem().Interface().(*build.Context)
+	ctx.Dir = l.tmpDir
+	return b, nil
+}
@@ -0,0 +1,121 @@
+package generators
+
+import (
+	"fmt"
+	"go/build"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+// writeCheckout writes a module checkout of k8s.io/kubelet with a single
+// package defining the given type, and returns its directory.
+func writeCheckout(t *testing.T, typeName string) string {
+	t.Helper()
+	checkout := t.TempDir()
+	pkgDir := filepath.Join(checkout, "config", "v1beta1")
+	if err := os.MkdirAll(pkgDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		filepath.Join(checkout, "go.mod"): "module k8s.io/kubelet\n\ngo 1.25.0\n",
+		filepath.Join(pkgDir, "types.go"): "package v1beta1\n\n// " + typeName + " is only defined in the checkout.\ntype " + typeName + " struct{}\n",
+	}
+	for path, content := range files {
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return checkout
+}
+
+func TestModuleLoaderLocalPath(t *testing.T) {
+	checkout := writeCheckout(t, "Local")
+	pkgDir := filepath.Join(checkout, "config", "v1beta1")
+
+	goflags := os.Getenv("GOFLAGS")
+	l, err := newModuleLoader([]APIDefinition{{Name: "kubelet-config", LocalPath: checkout}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.close()
+
+	dir, err := l.dir("k8s.io/kubelet/config/v1beta1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dir != pkgDir {
+		t.Errorf("Expected package in %s, got %s", pkgDir, dir)
+	}
+	if _, err := l.dir("k8s.io/kubelet/config/v1beta2"); err == nil {
+		t.Errorf("Expected an error for a package missing in the checkout")
+	}
+
+	b, err := l.newBuilder()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := b.AddDir("k8s.io/kubelet/config/v1beta1"); err != nil {
+		t.Fatal(err)
+	}
+	u, err := b.FindTypes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u.Package("k8s.io/kubelet/config/v1beta1").Types["Local"] == nil {
+		t.Errorf("Expected the types of the checkout to be parsed")
+	}
+	if v := os.Getenv("GOFLAGS"); v != goflags {
+		t.Errorf("Expected GOFLAGS to be unchanged, got %q", v)
+	}
+	if build.Default.Dir != "" {
+		t.Errorf("Expected the default build context to be unchanged, got directory %q", build.Default.Dir)
+	}
+}
+
+func TestModuleLoaderConcurrentBuilders(t *testing.T) {
+	typeNames := []string{"First", "Second", "Third"}
+	loaders := make([]*moduleLoader, len(typeNames))
+	for i, name := range typeNames {
+		l, err := newModuleLoader([]APIDefinition{{Name: "kubelet-config", LocalPath: writeCheckout(t, name)}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer l.close()
+		loaders[i] = l
+	}
+
+	var wg sync.WaitGroup
+	errs := make([]error, len(loaders))
+	for i, l := range loaders {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			b, err := l.newBuilder()
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			if err := b.AddDir("k8s.io/kubelet/config/v1beta1"); err != nil {
+				errs[i] = err
+				return
+			}
+			u, err := b.FindTypes()
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			if u.Package("k8s.io/kubelet/config/v1beta1").Types[typeNames[i]] == nil {
+				errs[i] = fmt.Errorf("type %s not found in the checkout", typeNames[i])
+			}
+		}()
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			t.Errorf("Loader %d: %v", i, err)
+		}
+	}
+}
@@ -0,0 +1,168 @@
+package generators
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"k8s.io/gengo/types"
+	"k8s.io/klog/v2"
+)
+
+// fieldConstraints is the set of defaulting and validation markers found in
+// the comments of a member.
+type fieldConstraints struct {
+	// Default is the raw default value from '+default=' or
+	// '+kubebuilder:default='.
+	Default string `json:"default,omitempty"`
+
+	// Enum lists the allowed values from '+kubebuilder:validation:Enum='.
+	Enum []string `json:"enum,omitempty"`
+
+	// Minimum is from '+kubebuilder:validation:Minimum='.
+	Minimum *float64 `json:"minimum,omitempty"`
+
+	// Maximum is from '+kubebuilder:validation:Maximum='.
+	Maximum *float64 `json:"maximum,omitempty"`
+
+	// Pattern is from '+kubebuilder:validation:Pattern='.
+	Pattern string `json:"pattern,omitempty"`
+
+	// MinLength is from '+kubebuilder:validation:MinLength='.
+	MinLength *int64 `json:"minLength,omitempty"`
+
+	// MaxLength is from '+kubebuilder:validation:MaxLength='.
+	MaxLength *int64 `json:"maxLength,omitempty"`
+
+	// ListType is from '+listType=', one of 'atomic', 'set' and 'map'.
+	ListType string `json:"listType,omitempty"`
+
+	// ListMapKeys is from '+listMapKey=', which can be specified more than
+	// once.
+	ListMapKeys []string `json:"listMapKeys,omitempty"`
+}
+
+// constraintItem is a name/value pair for displaying a constraint.
+type constraintItem struct {
+	Name  string
+	Value string
+}
+
+// parseConstraints extracts the defaulting and validation markers from the
+// comment lines of a member. It returns nil if no marker is found. The
+// markers are processed in the order of their names, so that the result does
+// not depend on the map iteration order. When both '+default' and
+// '+kubebuilder:default' are found, '+default' wins.
+func parseConstraints(comments []string) *fieldConstraints {
+	c := &fieldConstraints{}
+	found, hasDefault := false, false
+	tags := types.ExtractCommentTags("+", comments)
+	for _, name := range sortedKeys(tags) {
+		values := tags[name]
+		// '+kubebuilder:default:=value' is an accepted variant
+		key := strings.TrimSuffix(name, ":")
+		last := unquoteMarker(values[len(values)-1])
+		switch key {
+		case "default":
+			c.Default, hasDefault = last, true
+		case "kubebuilder:default":
+			if !hasDefault {
+				c.Default = last
+			}
+		case "kubebuilder:validation:Enum":
+			c.Enum = strings.Split(last, ";")
+		case "kubebuilder:validation:Minimum":
+			c.Minimum = parseFloatMarker(key, last)
+		case "kubebuilder:validation:Maximum":
+			c.Maximum = parseFloatMarker(key, last)
+		case "kubebuilder:validation:Pattern":
+			c.Pattern = last
+		case "kubebuilder:validation:MinLength":
+			c.MinLength = parseIntMarker(key, last)
+		case "kubebuilder:validation:MaxLength":
+			c.MaxLength = parseIntMarker(key, last)
+		case "listType":
+			c.ListType = last
+		case "listMapKey":
+			for _, v := range values {
+				c.ListMapKeys = append(c.ListMapKeys, unquoteMarker(v))
+			}
+		default:
+			continue
+		}
+		found = true
+	}
+	if !found {
+		return nil
+	}
+	return c
+}
+
+// unquoteMarker strips the quotes or backticks around a marker value.
+func unquoteMarker(v string) string {
+	v = strings.TrimSpace(v)
+	if len(v) >= 2 && v[0] == '`' && v[len(v)-1] == '`' {
+		return v[1 : len(v)-1]
+	}
+	if len(v) >= 2 && v[0] == '"' && v[len(v)-1] == '"' {
+		if s, err := strconv.Unquote(v); err == nil {
+			return s
+		}
+	}
+	return v
+}
+
+func parseFloatMarker(key, v string) *float64 {
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		klog.Warningf("Ignoring marker '+%s' with invalid value %q", key, v)
+		return nil
+	}
+	return &f
+}
+
+func parseIntMarker(key, v string) *int64 {
+	i, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		klog.Warningf("Ignoring marker '+%s' with invalid value %q", key, v)
+		return nil
+	}
+	return &i
+}
+
+// Items returns the constraints as a list of name/value pairs for display.
+func (c *fieldConstraints) Items() []constraintItem {
+	if c == nil {
+		return nil
+	}
+	var items []constraintItem
+	if c.Default != "" {
+		items = append(items, constraintItem{"Default", c.Default})
+	}
+	if len(c.Enum) > 0 {
+		items = append(items, constraintItem{"Enum", strings.Join(c.Enum, ", ")})
+	}
+	if c.Minimum != nil {
+		items = append(items, constraintItem{"Minimum", strconv.FormatFloat(*c.Minimum, 'g', -1, 64)})
+	}
+	if c.Maximum != nil {
+		items = append(items, constraintItem{"Maximum", strconv.FormatFloat(*c.Maximum, 'g', -1, 64)})
+	}
+	if c.Pattern != "" {
+		items = append(items, constraintItem{"Pattern", c.Pattern})
+	}
+	if c.MinLength != nil {
+		items = append(items, constraintItem{"MinLength", strconv.FormatInt(*c.MinLength, 10)})
+	}
+	if c.MaxLength != nil {
+		items = append(items, constraintItem{"MaxLength", strconv.FormatInt(*c.MaxLength, 10)})
+	}
+	if c.ListType != "" {
+		v := c.ListType
+		if len(c.ListMapKeys) > 0 {
+			v = fmt.Sprintf("%s (keys: %s)", v, strings.Join(c.ListMapKeys, ", "))
+		}
+		items = append(items, constraintItem{"ListType", v})
+	}
+	return items
+}
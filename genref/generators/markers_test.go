@@ -0,0 +1,116 @@
+package generators
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseConstraints(t *testing.T) {
+	min0 := float64(0)
+	max10 := float64(10)
+	len63 := int64(63)
+
+	tests := []struct {
+		Name     string
+		Comments []string
+		Expected *fieldConstraints
+	}{
+		{
+			Name:     "no markers",
+			Comments: []string{"Replicas is the number of replicas."},
+			Expected: nil,
+		},
+		{
+			Name:     "unrelated markers",
+			Comments: []string{"+optional", "+k8s:conversion-gen=false"},
+			Expected: nil,
+		},
+		{
+			Name:     "default",
+			Comments: []string{"+default=3"},
+			Expected: &fieldConstraints{Default: "3"},
+		},
+		{
+			Name:     "quoted default",
+			Comments: []string{`+kubebuilder:default="Always"`},
+			Expected: &fieldConstraints{Default: "Always"},
+		},
+		{
+			Name:     "kubebuilder default with colon",
+			Comments: []string{"+kubebuilder:default:=`{}`"},
+			Expected: &fieldConstraints{Default: "{}"},
+		},
+		{
+			Name:     "default wins over kubebuilder default",
+			Comments: []string{"+kubebuilder:default=1", "+default=2"},
+			Expected: &fieldConstraints{Default: "2"},
+		},
+		{
+			Name:     "empty default wins over kubebuilder default",
+			Comments: []string{`+default=""`, "+kubebuilder:default=a"},
+			Expected: &fieldConstraints{},
+		},
+		{
+			Name: "validation",
+			Comments: []string{
+				"+kubebuilder:validation:Enum=TCP;UDP",
+				"+kubebuilder:validation:Minimum=0",
+				"+kubebuilder:validation:Maximum=10",
+				"+kubebuilder:validation:MaxLength=63",
+				"+kubebuilder:validation:Pattern=`^[a-z]+$`",
+			},
+			Expected: &fieldConstraints{
+				Enum:      []string{"TCP", "UDP"},
+				Minimum:   &min0,
+				Maximum:   &max10,
+				MaxLength: &len63,
+				Pattern:   "^[a-z]+$",
+			},
+		},
+		{
+			Name:     "invalid number",
+			Comments: []string{"+kubebuilder:validation:Minimum=abc"},
+			Expected: &fieldConstraints{},
+		},
+		{
+			Name:     "list map keys",
+			Comments: []string{"+listType=map", "+listMapKey=name", "+listMapKey=protocol"},
+			Expected: &fieldConstraints{ListType: "map", ListMapKeys: []string{"name", "protocol"}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			// the result must not depend on the map iteration order
+			for i := 0; i < 10; i++ {
+				result := parseConstraints(test.Comments)
+				if !reflect.DeepEqual(result, test.Expected) {
+					t.Fatalf("Expected %+v, got %+v", test.Expected, result)
+				}
+			}
+		})
+	}
+}
+
+func TestConstraintItems(t *testing.T) {
+	min1 := float64(1)
+	c := &fieldConstraints{
+		Default:     "a",
+		Minimum:     &min1,
+		ListType:    "map",
+		ListMapKeys: []string{"name", "port"},
+	}
+	expected := []constraintItem{
+		{"Default", "a"},
+		{"Minimum", "1"},
+		{"ListType", "map (keys: name, port)"},
+	}
+	if result := c.Items(); !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+
+	var empty *fieldConstraints
+	if result := empty.Items(); result != nil {
+		t.Errorf("Expected no items, got %v", result)
+	}
+}
@@ -0,0 +1,272 @@
+package generators
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+// Field change types in a migration report
+const (
+	changeAdded       = "added"
+	changeRemoved     = "removed"
+	changeRenamed     = "renamed"
+	changeRetyped     = "retyped"
+	changeOptionality = "optionality"
+)
+
+// processedAPI is an API definition together with the packages parsed for it.
+type processedAPI struct {
+	Definition APIDefinition
+	Version    string
+	Packages   []*apiPackage
+}
+
+// migrationReport lists the changes between two versions of the same API.
+type migrationReport struct {
+	// Name is the name of the API definitions
+	Name string `json:"name"`
+	// Title is the title of the API without the version
+	Title string `json:"title"`
+
+	From           string `json:"from"`
+	To             string `json:"to"`
+	FromAPIVersion string `json:"fromAPIVersion"`
+	ToAPIVersion   string `json:"toAPIVersion"`
+
+	AddedKinds   []string      `json:"addedKinds,omitempty"`
+	RemovedKinds []string      `json:"removedKinds,omitempty"`
+	AddedTypes   []string      `json:"addedTypes,omitempty"`
+	RemovedTypes []string      `json:"removedTypes,omitempty"`
+	Types        []typeChanges `json:"types,omitempty"`
+}
+
+// typeChanges lists the field changes of a type present in both versions.
+type typeChanges struct {
+	Name    string        `json:"name"`
+	IsKind  bool          `json:"isKind"`
+	Changes []fieldChange `json:"changes"`
+}
+
+// fieldChange is a change to a field, identified by its JSON name.
+type fieldChange struct {
+	// Field is the JSON name of the field in the new version, or the old
+	// version if the field is removed.
+	Field string `json:"field"`
+	// Change is one of 'added', 'removed', 'renamed', 'retyped' and
+	// 'optionality'.
+	Change string `json:"change"`
+	// From and To describe the old and new values of what has changed:
+	// the JSON name, the type or the optionality.
+	From string `json:"from,omitempty"`
+	To   string `json:"to,omitempty"`
+}
+
+var versionPattern = regexp.MustCompile(`^v(\d+)(?:(alpha|beta)(\d+))?$`)
+
+// compareVersions compares two Kubernetes API versions such as 'v1beta2' and
+// 'v1' by their stability and numbers. It returns -1, 0 or 1.
+func compareVersions(a, b string) int {
+	ka, kb := versionKey(a), versionKey(b)
+	for i := range ka {
+		if ka[i] < kb[i] {
+			return -1
+		} else if ka[i] > kb[i] {
+			return 1
+		}
+	}
+	if a < b {
+		return -1
+	} else if a > b {
+		return 1
+	}
+	return 0
+}
+
+// versionKey returns a sortable key for a Kubernetes API version. Versions
+// that cannot be parsed sort first.
+func versionKey(v string) [3]int {
+	m := versionPattern.FindStringSubmatch(v)
+	if m == nil {
+		return [3]int{-1, -1, -1}
+	}
+	major, _ := strconv.Atoi(m[1])
+	stability, minor := 2, 0
+	switch m[2] {
+	case "alpha":
+		stability = 0
+	case "beta":
+		stability = 1
+	}
+	if m[3] != "" {
+		minor, _ = strconv.Atoi(m[3])
+	}
+	return [3]int{major, stability, minor}
+}
+
+var titleVersionPattern = regexp.MustCompile(`\s*\(v\d+((alpha|beta)\d+)?\)$`)
+
+// migrationReports groups the processed API definitions by name, sorts each
+// group by version, and compares every two consecutive versions.
+func migrationReports(apis []processedAPI) []*migrationReport {
+	groups := map[string][]processedAPI{}
+	var names []string
+	for _, api := range apis {
+		if _, ok := groups[api.Definition.Name]; !ok {
+			names = append(names, api.Definition.Name)
+		}
+		groups[api.Definition.Name] = append(groups[api.Definition.Name], api)
+	}
+
+	var reports []*migrationReport
+	for _, name := range names {
+		group := groups[name]
+		sort.SliceStable(group, func(i, j int) bool {
+			return compareVersions(group[i].Version, group[j].Version) < 0
+		})
+		for i := 1; i < len(group); i++ {
+			reports = append(reports, compareAPIs(group[i-1], group[i]))
+		}
+	}
+	return reports
+}
+
+// compareAPIs builds the migration report between two versions of an API.
+// Only the types from the main packages are compared.
+func compareAPIs(from, to processedAPI) *migrationReport {
+	r := &migrationReport{
+		Name:  from.Definition.Name,
+		Title: titleVersionPattern.ReplaceAllString(to.Definition.Title, ""),
+		From:  from.Version,
+		To:    to.Version,
+	}
+	oldTypes, oldKinds := documentedTypes(from.Packages, &r.FromAPIVersion)
+	newTypes, newKinds := documentedTypes(to.Packages, &r.ToAPIVersion)
+
+	for _, name := range sortedKeys(newTypes) {
+		if _, ok := oldTypes[name]; !ok {
+			if newKinds[name] {
+				r.AddedKinds = append(r.AddedKinds, name)
+			} else {
+				r.AddedTypes = append(r.AddedTypes, name)
+			}
+		}
+	}
+	for _, name := range sortedKeys(oldTypes) {
+		newType, ok := newTypes[name]
+		if !ok {
+			if oldKinds[name] {
+				r.RemovedKinds = append(r.RemovedKinds, name)
+			} else {
+				r.RemovedTypes = append(r.RemovedTypes, name)
+			}
+			continue
+		}
+		if changes := compareMembers(oldTypes[name], newType); len(changes) > 0 {
+			r.Types = append(r.Types, typeChanges{
+				Name:    name,
+				IsKind:  newKinds[name],
+				Changes: changes,
+			})
+		}
+	}
+	return r
+}
+
+// documentedTypes returns the types rendered for the main packages, keyed by
+// name, and the set of names that are top-level kinds. The apiVersion of the
+// main package is stored into apiVersion.
+func documentedTypes(pkgs []*apiPackage, apiVersion *string) (map[string]*apiType, map[string]bool) {
+	typeMap := map[string]*apiType{}
+	kinds := map[string]bool{}
+	for _, p := range pkgs {
+		if !p.IsMain {
+			continue
+		}
+		if *apiVersion == "" && p.GroupName() != "" {
+			*apiVersion = p.DisplayName()
+		}
+		for _, t := range p.VisibleTypes() {
+			if t.IsExported() {
+				kinds[t.Name.Name] = true
+			} else if !t.Referenced() {
+				continue
+			}
+			typeMap[t.Name.Name] = t
+		}
+	}
+	return typeMap, kinds
+}
+
+// compareMembers compares the serialized members of two versions of a type.
+func compareMembers(from, to *apiType) []fieldChange {
+	oldFields := map[string]*apiMember{}
+	for _, m := range from.GetMembers() {
+		if !m.Hidden() && m.FieldName() != "-" {
+			oldFields[m.FieldName()] = m
+		}
+	}
+
+	var changes []fieldChange
+	var added []*apiMember
+	for _, m := range to.GetMembers() {
+		if m.Hidden() || m.FieldName() == "-" {
+			continue
+		}
+		old, ok := oldFields[m.FieldName()]
+		if !ok {
+			added = append(added, m)
+			continue
+		}
+		delete(oldFields, m.FieldName())
+		if o, n := old.GetType().DisplayName(), m.GetType().DisplayName(); o != n {
+			changes = append(changes, fieldChange{Field: m.FieldName(), Change: changeRetyped, From: o, To: n})
+		}
+		if o, n := old.IsOptional(), m.IsOptional(); o != n {
+			changes = append(changes, fieldChange{
+				Field:  m.FieldName(),
+				Change: changeOptionality,
+				From:   optionality(o),
+				To:     optionality(n),
+			})
+		}
+	}
+
+	// A field with the same Go name but a different JSON name is renamed.
+	for _, m := range added {
+		var renamed *apiMember
+		for _, old := range oldFields {
+			if old.Name == m.Name {
+				renamed = old
+				break
+			}
+		}
+		if renamed != nil {
+			delete(oldFields, renamed.FieldName())
+			changes = append(changes, fieldChange{Field: m.FieldName(), Change: changeRenamed, From: renamed.FieldName(), To: m.FieldName()})
+			continue
+		}
+		changes = append(changes, fieldChange{Field: m.FieldName(), Change: changeAdded, To: m.GetType().DisplayName()})
+	}
+	for _, name := range sortedKeys(oldFields) {
+		changes = append(changes, fieldChange{Field: name, Change: changeRemoved, From: oldFields[name].GetType().DisplayName()})
+	}
+	return changes
+}
+
+func optionality(optional bool) string {
+	if optional {
+		return "optional"
+	}
+	return "required"
+}
+
+// sortedKeys returns the keys of a map in alphabetic order.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
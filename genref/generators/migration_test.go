@@ -0,0 +1,117 @@
+package generators
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	"k8s.io/gengo/types"
+)
+
+// testType builds a struct type with the given members, wrapped with an
+// empty type index.
+func testType(name string, members ...types.Member) *apiType {
+	return &apiType{
+		Type: types.Type{
+			Name:    types.Name{Package: "example.com/api", Name: name},
+			Kind:    types.Struct,
+			Members: members,
+		},
+		index: newTypeIndex(&Generator{}, "test"),
+	}
+}
+
+// testMember builds a member with a JSON name, optionally marked optional.
+func testMember(name, jsonName string, t *types.Type, optional bool) types.Member {
+	m := types.Member{Name: name, Type: t, Tags: `json:"` + jsonName + `,omitempty"`}
+	if optional {
+		m.CommentLines = []string{"+optional"}
+	}
+	return m
+}
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		A, B     string
+		Expected int
+	}{
+		{"v1", "v1", 0},
+		{"v1alpha1", "v1beta1", -1},
+		{"v1beta1", "v1alpha1", 1},
+		{"v1beta1", "v1beta2", -1},
+		{"v1beta2", "v1", -1},
+		{"v1", "v2alpha1", -1},
+		{"v2", "v1", 1},
+		{"v1beta10", "v1beta2", 1},
+		{"v1alpha3", "v1", -1},
+	}
+
+	for _, test := range tests {
+		if result := compareVersions(test.A, test.B); result != test.Expected {
+			t.Errorf("compareVersions(%q, %q): expected %d, got %d", test.A, test.B, test.Expected, result)
+		}
+	}
+
+	versions := []string{"v1", "v1beta1", "v2alpha1", "v1alpha2", "v1alpha1", "v1beta3"}
+	sort.Slice(versions, func(i, j int) bool { return compareVersions(versions[i], versions[j]) < 0 })
+	expected := []string{"v1alpha1", "v1alpha2", "v1beta1", "v1beta3", "v1", "v2alpha1"}
+	if !reflect.DeepEqual(versions, expected) {
+		t.Errorf("Expected %v, got %v", expected, versions)
+	}
+}
+
+func TestCompareMembers(t *testing.T) {
+	tests := []struct {
+		Name     string
+		From     []types.Member
+		To       []types.Member
+		Expected []fieldChange
+	}{
+		{
+			Name:     "unchanged",
+			From:     []types.Member{testMember("Port", "port", types.Int32, true)},
+			To:       []types.Member{testMember("Port", "port", types.Int32, true)},
+			Expected: nil,
+		},
+		{
+			Name: "added and removed",
+			From: []types.Member{testMember("Old", "old", types.String, true)},
+			To:   []types.Member{testMember("New", "new", types.Bool, true)},
+			Expected: []fieldChange{
+				{Field: "new", Change: changeAdded, To: "bool"},
+				{Field: "old", Change: changeRemoved, From: "string"},
+			},
+		},
+		{
+			Name: "renamed",
+			From: []types.Member{testMember("Address", "bindAddress", types.String, true)},
+			To:   []types.Member{testMember("Address", "address", types.String, true)},
+			Expected: []fieldChange{
+				{Field: "address", Change: changeRenamed, From: "bindAddress", To: "address"},
+			},
+		},
+		{
+			Name: "retyped and no longer optional",
+			From: []types.Member{testMember("Port", "port", types.Int32, true)},
+			To:   []types.Member{testMember("Port", "port", types.Int64, false)},
+			Expected: []fieldChange{
+				{Field: "port", Change: changeRetyped, From: "int32", To: "int64"},
+				{Field: "port", Change: changeOptionality, From: "optional", To: "required"},
+			},
+		},
+		{
+			Name: "ignored fields",
+			From: []types.Member{testMember("Internal", "-", types.String, true)},
+			To:   []types.Member{testMember("Other", "-", types.String, true)},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			result := compareMembers(testType("Config", test.From...), testType("Config", test.To...))
+			if !reflect.DeepEqual(result, test.Expected) {
+				t.Errorf("Expected %+v, got %+v", test.Expected, result)
+			}
+		})
+	}
+}
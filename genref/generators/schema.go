@@ -0,0 +1,328 @@
+package generators
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"k8s.io/gengo/types"
+	"k8s.io/klog/v2"
+)
+
+// jsonSchemaDraft is the JSON Schema dialect of the generated documents. Draft
+// 07 is the one best supported by editors such as yaml-language-server.
+const jsonSchemaDraft = "http://json-schema.org/draft-07/schema#"
+
+// schema is a (partial) JSON Schema document. Only the keywords needed for
+// describing Go API types are modeled.
+type schema struct {
+	Schema               string             `json:"$schema,omitempty"`
+	ID                   string             `json:"$id,omitempty"`
+	Ref                  string             `json:"$ref,omitempty"`
+	Title                string             `json:"title,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Enum                 []interface{}      `json:"enum,omitempty"`
+	Default              interface{}        `json:"default,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	Pattern              string             `json:"pattern,omitempty"`
+	MinLength            *int64             `json:"minLength,omitempty"`
+	MaxLength            *int64             `json:"maxLength,omitempty"`
+	Items                *schema            `json:"items,omitempty"`
+	Properties           map[string]*schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties interface{}        `json:"additionalProperties,omitempty"`
+	AllOf                []*schema          `json:"allOf,omitempty"`
+	AnyOf                []*schema          `json:"anyOf,omitempty"`
+	Definitions          map[string]*schema `json:"definitions,omitempty"`
+}
+
+// wellKnownSchemas maps types from external packages which have a custom
+// serialization to their JSON schemas. These types are never expanded.
+var wellKnownSchemas = map[string]schema{
+	"k8s.io/apimachinery/pkg/apis/meta/v1.Duration": {
+		Type:        "string",
+		Description: "A duration string such as \"300ms\", \"1.5h\" or \"2h45m\".",
+	},
+	"k8s.io/apimachinery/pkg/apis/meta/v1.Time": {
+		Type:   "string",
+		Format: "date-time",
+	},
+	"k8s.io/apimachinery/pkg/apis/meta/v1.MicroTime": {
+		Type:   "string",
+		Format: "date-time",
+	},
+	"k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta": {
+		Type: "object",
+	},
+	"k8s.io/apimachinery/pkg/api/resource.Quantity": {
+		AnyOf: []*schema{{Type: "string"}, {Type: "number"}},
+	},
+	"k8s.io/apimachinery/pkg/api/resource.QuantityValue": {
+		AnyOf: []*schema{{Type: "string"}, {Type: "number"}},
+	},
+	"k8s.io/apimachinery/pkg/util/intstr.IntOrString": {
+		AnyOf: []*schema{{Type: "integer"}, {Type: "string"}},
+	},
+	"k8s.io/apimachinery/pkg/runtime.RawExtension": {
+		Type: "object",
+	},
+	"k8s.io/apimachinery/pkg/runtime.Unknown": {
+		Type: "object",
+	},
+	"time.Duration": {
+		Type:        "integer",
+		Description: "A duration in nanoseconds.",
+	},
+}
+
+// schemaBuilder builds the schema for a top-level kind, collecting the
+// definitions of all the struct types reachable from it.
+type schemaBuilder struct {
+	definitions map[string]*schema
+}
+
+// definitionName returns the key of a type in the "definitions" section.
+func definitionName(t *apiType) string {
+	return strings.ReplaceAll(t.Name.String(), "/", ".")
+}
+
+// schemaFor returns the schema for a type, adding the definitions for struct
+// types as needed.
+func (b *schemaBuilder) schemaFor(t *apiType) *schema {
+	if s, ok := wellKnownSchemas[t.Name.String()]; ok {
+		return &s
+	}
+
+	switch t.Kind {
+	case types.Builtin:
+		return builtinSchema(t.Name.Name)
+	case types.Pointer:
+		return b.schemaFor(t.wrap(t.Elem))
+	case types.Slice, types.Array:
+		if t.Elem.Kind == types.Builtin && t.Elem.Name.Name == "byte" {
+			return &schema{Type: "string", Format: "byte"}
+		}
+		return &schema{Type: "array", Items: b.schemaFor(t.wrap(t.Elem))}
+	case types.Map:
+		return &schema{Type: "object", AdditionalProperties: b.schemaFor(t.wrap(t.Elem))}
+	case types.Alias:
+		s := b.schemaFor(t.wrap(t.Underlying))
+		if desc := commentText(t.CommentLines); desc != "" && s.Ref == "" {
+			s.Description = desc
+		}
+		if values := t.AllowedValues(); len(values) > 0 {
+			target := keywordTarget(s)
+			for _, v := range values {
+				target.Enum = append(target.Enum, v.Value)
+			}
+		}
+		return s
+	case types.Struct:
+		name := definitionName(t)
+		if _, ok := b.definitions[name]; !ok {
+			// Register first so that recursive types terminate.
+			def := &schema{}
+			b.definitions[name] = def
+			*def = *b.structSchema(t)
+		}
+		return &schema{Ref: "#/definitions/" + name}
+	case types.Interface:
+		return &schema{}
+	}
+
+	klog.Warningf("Type '%s' has kind='%v' which has no JSON schema mapping", t.Name, t.Kind)
+	return &schema{}
+}
+
+// structSchema returns the schema for the members of a struct.
+func (b *schemaBuilder) structSchema(t *apiType) *schema {
+	s := &schema{
+		Type:                 "object",
+		Description:          commentText(t.CommentLines),
+		Properties:           map[string]*schema{},
+		AdditionalProperties: false,
+	}
+	b.addMembers(s, t)
+	return s
+}
+
+// addMembers adds the members of a struct to the properties of a schema,
+// flattening inline members.
+func (b *schemaBuilder) addMembers(s *schema, t *apiType) {
+	for _, m := range t.GetMembers() {
+		tag := reflect.StructTag(m.Tags).Get("json")
+		if tag == "-" {
+			continue
+		}
+		mt := m.GetType()
+		if m.IsInline() || (m.Embedded && tag == "") {
+			if m.Hidden() {
+				continue
+			}
+			if _, ok := wellKnownSchemas[mt.deref().Name.String()]; !ok && mt.deref().Kind == types.Struct {
+				b.addMembers(s, mt.deref())
+				continue
+			}
+		}
+
+		prop := b.schemaFor(mt)
+		desc := commentText(m.CommentLines)
+		if prop.Ref != "" && (desc != "" || m.Constraints != nil) {
+			// Keywords next to "$ref" are ignored by draft 07 validators.
+			prop = &schema{AllOf: []*schema{prop}}
+		}
+		if desc != "" {
+			prop.Description = desc
+		}
+		applyConstraints(prop, m.Constraints)
+		s.Properties[m.FieldName()] = prop
+		if !m.IsOptional() && !strings.Contains(tag, ",omitempty") {
+			s.Required = append(s.Required, m.FieldName())
+		}
+	}
+}
+
+// applyConstraints adds the validation keywords from the markers of a member
+// to its schema. Value constraints of a list apply to its items.
+func applyConstraints(s *schema, c *fieldConstraints) {
+	if c == nil {
+		return
+	}
+	if c.Default != "" {
+		s.Default = markerValue(s, c.Default)
+	}
+	if len(c.Enum) == 0 && c.Minimum == nil && c.Maximum == nil &&
+		c.Pattern == "" && c.MinLength == nil && c.MaxLength == nil {
+		return
+	}
+
+	target := s
+	if s.Type == "array" && s.Items != nil {
+		items := *s.Items
+		target = &items
+		s.Items = target
+	}
+	target = keywordTarget(target)
+	for _, v := range c.Enum {
+		target.Enum = append(target.Enum, markerValue(target, v))
+	}
+	target.Minimum = c.Minimum
+	target.Maximum = c.Maximum
+	target.Pattern = c.Pattern
+	target.MinLength = c.MinLength
+	target.MaxLength = c.MaxLength
+}
+
+// keywordTarget returns the schema to add validation keywords to. Keywords
+// next to "$ref" are ignored by draft 07 validators, so a reference is moved
+// into an "allOf" and the keywords go into a new element of it.
+func keywordTarget(s *schema) *schema {
+	if s.Ref != "" {
+		s.AllOf = append(s.AllOf, &schema{Ref: s.Ref})
+		s.Ref = ""
+	}
+	if len(s.AllOf) == 0 {
+		return s
+	}
+	k := &schema{}
+	s.AllOf = append(s.AllOf, k)
+	return k
+}
+
+// markerValue converts a marker value to a JSON value for a schema. Values
+// for strings are kept as is, others fall back to a plain string if they are
+// not valid JSON.
+func markerValue(s *schema, v string) interface{} {
+	if s.Type == "string" {
+		return v
+	}
+	var out interface{}
+	if err := json.Unmarshal([]byte(v), &out); err != nil {
+		return v
+	}
+	return out
+}
+
+// builtinSchema maps a Go builtin type to its JSON schema.
+func builtinSchema(name string) *schema {
+	switch name {
+	case "string":
+		return &schema{Type: "string"}
+	case "bool":
+		return &schema{Type: "boolean"}
+	case "int", "int8", "int16", "int32", "int64",
+		"uint", "uint8", "uint16", "uint32", "uint64", "byte":
+		return &schema{Type: "integer"}
+	case "float32", "float64":
+		return &schema{Type: "number"}
+	}
+	return &schema{}
+}
+
+// kindSchema builds the JSON schema document for a top-level kind.
+func kindSchema(p *apiPackage, t *apiType) *schema {
+	b := &schemaBuilder{definitions: map[string]*schema{}}
+	s := b.structSchema(t)
+	s.Schema = jsonSchemaDraft
+	s.Title = t.Name.Name
+
+	apiVersion := p.apiVersion
+	if p.apiGroup != "" {
+		apiVersion = p.DisplayName()
+	}
+	s.Properties["apiVersion"] = &schema{Type: "string", Enum: []interface{}{apiVersion}}
+	s.Properties["kind"] = &schema{Type: "string", Enum: []interface{}{t.Name.Name}}
+	required := []string{"apiVersion", "kind"}
+	for _, r := range s.Required {
+		if r != "apiVersion" && r != "kind" {
+			required = append(required, r)
+		}
+	}
+	s.Required = required
+
+	if len(b.definitions) > 0 {
+		s.Definitions = b.definitions
+	}
+	return s
+}
+
+// schemaResults generates one JSON schema document for each top-level kind in
+// the main packages, to be written into the specified directory.
+func schemaResults(pkgs []*apiPackage, dir string) ([]Result, error) {
+	var results []Result
+	for _, p := range pkgs {
+		if !p.IsMain {
+			continue
+		}
+		for _, t := range p.VisibleTypes() {
+			if !t.IsExported() || t.Kind != types.Struct {
+				continue
+			}
+			b, err := json.MarshalIndent(kindSchema(p, t), "", "  ")
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal schema for %s: %w", t.Name, err)
+			}
+			results = append(results, Result{
+				Path:    filepath.Join(dir, t.Name.Name+".json"),
+				Content: append(b, '\n'),
+			})
+		}
+	}
+	return results, nil
+}
+
+// commentText returns the plain text of comment lines with tags removed.
+func commentText(comments []string) string {
+	var list []string
+	for _, v := range comments {
+		if !strings.HasPrefix(strings.TrimSpace(v), "+") {
+			list = append(list, v)
+		}
+	}
+	return strings.TrimSpace(strings.Join(list, "\n"))
+}
@@ -0,0 +1,116 @@
+package generators
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"k8s.io/gengo/types"
+)
+
+var update = flag.Bool("update", false, "update the golden files in testdata")
+
+// markedMember builds an optional member with the given comment lines.
+func markedMember(name, jsonName string, t *types.Type, comments ...string) types.Member {
+	m := testMember(name, jsonName, t, true)
+	m.CommentLines = append(comments, m.CommentLines...)
+	return m
+}
+
+// testSchemaKinds returns the kinds whose schemas are compared with the
+// golden files, by name.
+func testSchemaKinds() map[string]*apiType {
+	ref := &types.Type{
+		Name:         types.Name{Package: "example.com/api", Name: "WidgetReference"},
+		Kind:         types.Struct,
+		CommentLines: []string{"WidgetReference refers to another widget."},
+		Members:      []types.Member{testMember("Name", "name", types.String, false)},
+	}
+	refs := &types.Type{Kind: types.Slice, Elem: ref}
+	names := &types.Type{Kind: types.Slice, Elem: types.String}
+	replicas := &types.Type{Kind: types.Pointer, Elem: types.Int32}
+
+	widget := testType("Widget",
+		markedMember("Name", "name", types.String,
+			"Name of the widget.",
+			"+kubebuilder:validation:MinLength=1",
+			"+kubebuilder:validation:MaxLength=63"),
+		markedMember("Replicas", "replicas", replicas,
+			"Replicas is the number of copies.",
+			"+default=1",
+			"+kubebuilder:validation:Minimum=0",
+			"+kubebuilder:validation:Maximum=10"),
+		markedMember("Parent", "parent", ref, "Parent of the widget."),
+		markedMember("Owner", "owner", ref,
+			"Owner of the widget.",
+			`+default={"name":"root"}`,
+			`+kubebuilder:validation:Enum={"name":"root"};{"name":"admin"}`),
+		markedMember("Children", "children", refs,
+			`+kubebuilder:validation:Enum={"name":"first"};{"name":"second"}`),
+		markedMember("Tags", "tags", names,
+			"Tags of the widget.",
+			"+kubebuilder:validation:Pattern=^[a-z]+$"),
+		testMember("Size", "size", types.Int64, false),
+	)
+	return map[string]*apiType{"Widget": widget}
+}
+
+func TestKindSchemaGolden(t *testing.T) {
+	p := &apiPackage{apiGroup: "example.com", apiVersion: "v1"}
+	for name, kind := range testSchemaKinds() {
+		t.Run(name, func(t *testing.T) {
+			b, err := json.MarshalIndent(kindSchema(p, kind), "", "  ")
+			if err != nil {
+				t.Fatal(err)
+			}
+			b = append(b, '\n')
+
+			golden := filepath.Join("testdata", "schema", name+".json")
+			if *update {
+				if err := os.MkdirAll(filepath.Dir(golden), 0755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(golden, b, 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			expected, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatalf("%v (run the test with -update to write the golden file)", err)
+			}
+			if !bytes.Equal(b, expected) {
+				t.Errorf("Schema differs from %s, got:\n%s", golden, b)
+			}
+		})
+	}
+}
+
+func TestKeywordTarget(t *testing.T) {
+	tests := []struct {
+		Name   string
+		Schema *schema
+		Same   bool
+		AllOf  int
+	}{
+		{Name: "plain schema", Schema: &schema{Type: "string"}, Same: true},
+		{Name: "reference", Schema: &schema{Ref: "#/definitions/a"}, AllOf: 2},
+		{Name: "allOf wrapper", Schema: &schema{AllOf: []*schema{{Ref: "#/definitions/a"}}}, AllOf: 2},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			target := keywordTarget(test.Schema)
+			if (target == test.Schema) != test.Same {
+				t.Errorf("Expected the schema itself to be returned: %v", test.Same)
+			}
+			if test.Schema.Ref != "" {
+				t.Errorf("Expected no keyword next to $ref, got %q", test.Schema.Ref)
+			}
+			if len(test.Schema.AllOf) != test.AllOf {
+				t.Errorf("Expected %d allOf elements, got %d", test.AllOf, len(test.Schema.AllOf))
+			}
+		})
+	}
+}
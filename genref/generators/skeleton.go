@@ -0,0 +1,182 @@
+package generators
+
+import (
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"k8s.io/gengo/types"
+	"sigs.k8s.io/yaml"
+)
+
+// skeletonWriter writes an annotated YAML skeleton for a top-level kind.
+type skeletonWriter struct {
+	b strings.Builder
+	// visiting guards against recursive types
+	visiting map[string]bool
+}
+
+// Skeleton returns the annotated YAML skeleton for a top-level kind when the
+// examples are enabled. It returns an empty string otherwise.
+func (t *apiType) Skeleton() string {
+	if !t.index.opts.Examples || !t.IsExported() || t.Kind != types.Struct {
+		return ""
+	}
+	return kindSkeleton(t)
+}
+
+// kindSkeleton generates a YAML skeleton for a kind. Each field is annotated
+// with the first sentence of its comment and its type. Optional fields are
+// commented out.
+func kindSkeleton(t *apiType) string {
+	w := &skeletonWriter{visiting: map[string]bool{}}
+	fmt.Fprintf(&w.b, "apiVersion: %s\n", strings.TrimPrefix(t.APIGroup(), "/"))
+	fmt.Fprintf(&w.b, "kind: %s\n", t.Name.Name)
+	w.writeMembers(t, 0, false)
+	return w.b.String()
+}
+
+// writeMembers writes the fields of a struct, flattening inline members.
+func (w *skeletonWriter) writeMembers(t *apiType, indent int, commented bool) {
+	t = t.deref()
+	if w.visiting[t.String()] {
+		return
+	}
+	w.visiting[t.String()] = true
+	defer delete(w.visiting, t.String())
+
+	for _, m := range t.GetMembers() {
+		tag := reflect.StructTag(m.Tags).Get("json")
+		if m.Hidden() || tag == "-" {
+			continue
+		}
+		if m.IsInline() || (m.Embedded && tag == "") {
+			w.writeMembers(m.GetType(), indent, commented)
+			continue
+		}
+		w.writeField(m, indent, commented || m.IsOptional())
+	}
+}
+
+// writeField writes a field, preceded by the first sentence of its comment.
+func (w *skeletonWriter) writeField(m *apiMember, indent int, commented bool) {
+	prefix := strings.Repeat(" ", indent)
+	if commented {
+		prefix += "# "
+	}
+	if s := firstSentence(commentText(m.CommentLines)); s != "" {
+		fmt.Fprintf(&w.b, "%s# %s\n", strings.Repeat(" ", indent), s)
+	}
+
+	mt := m.GetType()
+	typeName := mt.DisplayName()
+	elem := mt.deref()
+	if mt.Kind == types.Pointer {
+		mt = elem
+	}
+
+	switch {
+	case isExpandable(mt):
+		if !hasVisibleMembers(mt) {
+			fmt.Fprintf(&w.b, "%s%s: {}  # %s\n", prefix, m.FieldName(), typeName)
+			return
+		}
+		fmt.Fprintf(&w.b, "%s%s:  # %s\n", prefix, m.FieldName(), typeName)
+		w.writeMembers(mt, indent+2, commented)
+	case mt.Kind == types.Slice && isExpandable(elem):
+		fmt.Fprintf(&w.b, "%s%s:  # %s\n", prefix, m.FieldName(), typeName)
+		itemPrefix := strings.Repeat(" ", indent+2)
+		if commented {
+			itemPrefix += "# "
+		}
+		fmt.Fprintf(&w.b, "%s-\n", itemPrefix)
+		w.writeMembers(elem, indent+4, commented)
+	default:
+		fmt.Fprintf(&w.b, "%s%s: %s  # %s\n", prefix, m.FieldName(), skeletonValue(m), typeName)
+	}
+}
+
+// isExpandable tests if a type is a struct whose fields are written into the
+// skeleton, i.e. a local one without a custom serialization. The fields of
+// external types are documented elsewhere.
+func isExpandable(t *apiType) bool {
+	if _, ok := wellKnownSchemas[t.Name.String()]; ok {
+		return false
+	}
+	return t.Kind == types.Struct && t.isLocal()
+}
+
+// hasVisibleMembers tests if a struct has any field to write.
+func hasVisibleMembers(t *apiType) bool {
+	for _, m := range t.GetMembers() {
+		if !m.Hidden() && reflect.StructTag(m.Tags).Get("json") != "-" {
+			return true
+		}
+	}
+	return false
+}
+
+// skeletonValue returns the YAML value for a scalar, list or map field: the
+// default value if known, or the zero value of the type.
+func skeletonValue(m *apiMember) string {
+	s := (&schemaBuilder{definitions: map[string]*schema{}}).schemaFor(m.GetType())
+	var v interface{}
+	if m.Constraints != nil && m.Constraints.Default != "" {
+		v = markerValue(s, m.Constraints.Default)
+	} else {
+		switch s.Type {
+		case "string":
+			v = ""
+		case "boolean":
+			v = false
+		case "integer", "number":
+			v = 0
+		case "array":
+			v = []interface{}{}
+		case "object":
+			v = map[string]interface{}{}
+		default:
+			if s.Ref != "" {
+				v = map[string]interface{}{}
+			} else if len(s.AnyOf) > 0 {
+				v = ""
+			}
+		}
+	}
+	b, err := yaml.Marshal(v)
+	if err != nil {
+		return "null"
+	}
+	return strings.TrimSpace(string(b))
+}
+
+// firstSentence returns the first sentence of a comment in a single line.
+func firstSentence(s string) string {
+	s = strings.Join(strings.Fields(s), " ")
+	if i := strings.Index(s, ". "); i >= 0 {
+		return s[:i+1]
+	}
+	return s
+}
+
+// skeletonResults generates the YAML skeleton for each top-level kind in the
+// main packages, to be written into the specified directory.
+func skeletonResults(pkgs []*apiPackage, dir string) []Result {
+	var results []Result
+	for _, p := range pkgs {
+		if !p.IsMain {
+			continue
+		}
+		for _, t := range p.VisibleTypes() {
+			if !t.IsExported() || t.Kind != types.Struct {
+				continue
+			}
+			results = append(results, Result{
+				Path:    filepath.Join(dir, t.Name.Name+".yaml"),
+				Content: []byte(kindSkeleton(t)),
+			})
+		}
+	}
+	return results
+}
@@ -0,0 +1,96 @@
+package generators
+
+import (
+	"testing"
+
+	"k8s.io/gengo/types"
+)
+
+// testSkeletonKind returns a kind with a nested struct, a list of structs, a
+// recursive field and an inline member, registered in an example.com/v1
+// package.
+func testSkeletonKind() *apiType {
+	node := &types.Type{
+		Name: types.Name{Package: "example.com/api", Name: "Node"},
+		Kind: types.Struct,
+	}
+	node.Members = []types.Member{
+		markedMember("Name", "name", types.String, "Name of the node. It is unique."),
+		markedMember("Next", "next", &types.Type{Kind: types.Pointer, Elem: node}, "Next node, if any."),
+	}
+	common := &types.Type{
+		Name:    types.Name{Package: "example.com/api", Name: "Common"},
+		Kind:    types.Struct,
+		Members: []types.Member{testMember("Labels", "labels", &types.Type{Kind: types.Map, Key: types.String, Elem: types.String}, false)},
+	}
+	empty := &types.Type{Name: types.Name{Package: "example.com/api", Name: "Empty"}, Kind: types.Struct}
+
+	widget := testType("Widget",
+		types.Member{Name: "Common", Type: common, Embedded: true, Tags: `json:",inline"`},
+		markedMember("Replicas", "replicas", types.Int32, "Replicas is the number of copies.", "+default=3"),
+		testMember("Enabled", "enabled", types.Bool, false),
+		testMember("Root", "root", node, false),
+		testMember("Nodes", "nodes", &types.Type{Kind: types.Slice, Elem: node}, true),
+		testMember("Extra", "extra", empty, false),
+		types.Member{Name: "Internal", Type: types.String, Tags: `json:"-"`},
+	)
+	p := &apiPackage{apiGroup: "example.com", apiVersion: "v1"}
+	for _, t := range []*types.Type{&widget.Type, node, common, empty} {
+		widget.index.typePkgMap[t.String()] = p
+	}
+	return widget
+}
+
+func TestKindSkeleton(t *testing.T) {
+	expected := `apiVersion: example.com/v1
+kind: Widget
+labels: {}  # map[string]string
+# Replicas is the number of copies.
+# replicas: 3  # int32
+enabled: false  # bool
+root:  # Node
+  # Name of the node.
+  # name: ""  # string
+  # Next node, if any.
+  # next:  # Node
+# nodes:  # []Node
+  # -
+    # Name of the node.
+    # name: ""  # string
+    # Next node, if any.
+    # next:  # Node
+extra: {}  # Empty
+`
+	if actual := kindSkeleton(testSkeletonKind()); actual != expected {
+		t.Errorf("Expected skeleton:\n%s\ngot:\n%s", expected, actual)
+	}
+}
+
+func TestSkeletonExamplesOption(t *testing.T) {
+	widget := testSkeletonKind()
+	if s := widget.Skeleton(); s != "" {
+		t.Errorf("Expected no skeleton without the examples option, got:\n%s", s)
+	}
+	widget.index.opts.Examples = true
+	if s := widget.Skeleton(); s != "" {
+		t.Errorf("Expected no skeleton for a type that is not a kind, got:\n%s", s)
+	}
+	widget.CommentLines = []string{"+genclient"}
+	if s := widget.Skeleton(); s == "" {
+		t.Errorf("Expected a skeleton with the examples option")
+	}
+}
+
+func TestFirstSentence(t *testing.T) {
+	tests := map[string]string{
+		"":                                   "",
+		"Name of the widget.":                "Name of the widget.",
+		"Name of the\nwidget. It is unique.": "Name of the widget.",
+		"Version is v1.2 or later":           "Version is v1.2 or later",
+	}
+	for s, expected := range tests {
+		if actual := firstSentence(s); actual != expected {
+			t.Errorf("firstSentence(%q): expected %q, got %q", s, expected, actual)
+		}
+	}
+}
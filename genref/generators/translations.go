@@ -0,0 +1,185 @@
+package generators
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"html/template"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"sigs.k8s.io/yaml"
+)
+
+// Kinds of translation issues
+const (
+	// IssueUntranslated is for a comment without a translation.
+	IssueUntranslated = "untranslated"
+	// IssueStale is for a translation made from an older source comment.
+	IssueStale = "stale"
+)
+
+// TranslationIssue is a comment whose translation is missing or out of date.
+type TranslationIssue struct {
+	// Kind is either 'untranslated' or 'stale'.
+	Kind string `json:"kind"`
+
+	// Key is the key of the overlay entry, i.e. the package path, the type
+	// identifier in the format of PackagePath.Name, or the field identifier
+	// in the format of PackagePath.Name.Field.
+	Key string `json:"key"`
+
+	// Hash is the hash of the current source comment, to be recorded in the
+	// overlay entry along with the translation.
+	Hash string `json:"hash"`
+
+	// Source is the current source comment.
+	Source string `json:"source"`
+
+	// APIs lists the names of the API definitions where the comment is found.
+	APIs []string `json:"apis"`
+}
+
+// translation is an entry in an overlay file.
+type translation struct {
+	// Hash is the hash of the source comment that is translated.
+	Hash string `json:"hash,omitempty"`
+
+	// Title replaces the title of a package.
+	Title string `json:"title,omitempty"`
+
+	// Comment replaces the comment of a package, a type or a field.
+	Comment string `json:"comment,omitempty"`
+}
+
+// loadOverlay reads the overlay files in the <dir>/<lang> directory. Each
+// file is a map from the keys to the translations.
+func loadOverlay(dir, lang string) (map[string]translation, error) {
+	files, err := filepath.Glob(filepath.Join(dir, lang, "*.yaml"))
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no overlay files found for language %q in %s", lang, filepath.Join(dir, lang))
+	}
+
+	overlay := make(map[string]translation)
+	for _, fn := range files {
+		data, err := os.ReadFile(fn)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read overlay file: %w", err)
+		}
+		var entries map[string]translation
+		if err := yaml.UnmarshalStrict(data, &entries); err != nil {
+			return nil, fmt.Errorf("failed to parse overlay file %s: %w", fn, err)
+		}
+		for k, v := range entries {
+			if _, ok := overlay[k]; ok {
+				return nil, fmt.Errorf("duplicate overlay entry %q in %s", k, fn)
+			}
+			overlay[k] = v
+		}
+	}
+	return overlay, nil
+}
+
+// sourceComment returns the comment lines without the markers, the same as
+// what is rendered.
+func sourceComment(comments []string) string {
+	var list []string
+	for _, v := range comments {
+		if !strings.HasPrefix(strings.TrimSpace(v), "+") {
+			list = append(list, strings.TrimRight(v, " \t"))
+		}
+	}
+	return strings.TrimSpace(strings.Join(list, "\n"))
+}
+
+// commentHash returns a short hash of a source comment.
+func commentHash(source string) string {
+	sum := sha256.Sum256([]byte(source))
+	return hex.EncodeToString(sum[:])[:12]
+}
+
+// translationReport collects the translation issues without duplicates. It is
+// safe for concurrent use.
+type translationReport struct {
+	mu     sync.Mutex
+	issues map[string]*TranslationIssue
+}
+
+func newTranslationReport() *translationReport {
+	return &translationReport{issues: make(map[string]*TranslationIssue)}
+}
+
+// add records an issue found for an API definition.
+func (r *translationReport) add(kind, key, hash, source, api string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	issue, ok := r.issues[key]
+	if !ok {
+		issue = &TranslationIssue{Kind: kind, Key: key, Hash: hash, Source: source}
+		r.issues[key] = issue
+	}
+	if api != "" && !containsString(issue.APIs, api) {
+		issue.APIs = append(issue.APIs, api)
+		sort.Strings(issue.APIs)
+	}
+}
+
+// list returns the issues sorted by key.
+func (r *translationReport) list() []TranslationIssue {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	result := make([]TranslationIssue, 0, len(r.issues))
+	for _, key := range sortedKeys(r.issues) {
+		result = append(result, *r.issues[key])
+	}
+	return result
+}
+
+// TranslationIssues returns the untranslated and stale comments found by the
+// last run of Generate. It is empty if no language is specified.
+func (g *Generator) TranslationIssues() []TranslationIssue {
+	if g.translations == nil {
+		return nil
+	}
+	return g.translations.list()
+}
+
+// translatedComment renders the translation of a comment when a language is
+// specified, or the comment itself otherwise. The comment is rendered if it
+// is not translated yet.
+func (idx *typeIndex) translatedComment(key string, comments []string) template.HTML {
+	if idx.overlay == nil {
+		return idx.renderComments(comments)
+	}
+	source := sourceComment(comments)
+	if source == "" {
+		return idx.renderComments(comments)
+	}
+
+	hash := commentHash(source)
+	entry, ok := idx.overlay[key]
+	if !ok || entry.Comment == "" {
+		idx.translations.add(IssueUntranslated, key, hash, source, idx.api)
+		return idx.renderComments(comments)
+	}
+	if entry.Hash != hash {
+		idx.translations.add(IssueStale, key, hash, source, idx.api)
+	}
+	return idx.renderComments(strings.Split(entry.Comment, "\n"))
+}
+
+// translatedTitle returns the translated title of a package, if any.
+func (idx *typeIndex) translatedTitle(key, title string) string {
+	if entry, ok := idx.overlay[key]; ok && entry.Title != "" && title != "" {
+		return entry.Title
+	}
+	return title
+}
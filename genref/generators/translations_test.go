@@ -0,0 +1,191 @@
+package generators
+
+import (
+	"html/template"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// writeOverlay writes overlay files for the 'ja' language and returns the
+// translation directory.
+func writeOverlay(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "ja"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, "ja", name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestLoadOverlay(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Files    map[string]string
+		Lang     string
+		Expected map[string]translation
+		Error    bool
+	}{
+		{
+			Name: "several files",
+			Files: map[string]string{
+				"kubelet.yaml": "example.com/api:\n  title: API\n",
+				"kubeadm.yaml": "example.com/api.Foo:\n  hash: abc\n  comment: Foo です。\n",
+				"notes.txt":    "ignored",
+			},
+			Lang: "ja",
+			Expected: map[string]translation{
+				"example.com/api":     {Title: "API"},
+				"example.com/api.Foo": {Hash: "abc", Comment: "Foo です。"},
+			},
+		},
+		{
+			Name: "duplicate entry",
+			Files: map[string]string{
+				"a.yaml": "example.com/api.Foo:\n  comment: a\n",
+				"b.yaml": "example.com/api.Foo:\n  comment: b\n",
+			},
+			Lang:  "ja",
+			Error: true,
+		},
+		{
+			Name:  "unknown field",
+			Files: map[string]string{"a.yaml": "example.com/api.Foo:\n  comments: a\n"},
+			Lang:  "ja",
+			Error: true,
+		},
+		{
+			Name:  "language without files",
+			Files: map[string]string{"a.yaml": "example.com/api.Foo:\n  comment: a\n"},
+			Lang:  "ko",
+			Error: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			overlay, err := loadOverlay(writeOverlay(t, test.Files), test.Lang)
+			if test.Error {
+				if err == nil {
+					t.Errorf("Expected an error, got %v", overlay)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(overlay, test.Expected) {
+				t.Errorf("Expected overlay %v, got %v", test.Expected, overlay)
+			}
+		})
+	}
+}
+
+func TestSourceComment(t *testing.T) {
+	comments := []string{" Foo is an example. ", "+optional", "", " It has two lines.\t", "+default=1"}
+	expected := "Foo is an example.\n\n It has two lines."
+	if actual := sourceComment(comments); actual != expected {
+		t.Errorf("Expected %q, got %q", expected, actual)
+	}
+	// markers and trailing spaces do not change the hash
+	if commentHash(sourceComment(comments)) != commentHash(sourceComment([]string{"Foo is an example.", "", " It has two lines."})) {
+		t.Errorf("Expected the same hash for the same source comment")
+	}
+}
+
+func TestTranslatedComment(t *testing.T) {
+	current := []string{"Foo is an example."}
+	hash := commentHash(sourceComment(current))
+	overlay := map[string]translation{
+		"example.com/api.Current": {Hash: hash, Comment: "Foo は例です。"},
+		"example.com/api.Stale":   {Hash: "000000000000", Comment: "古い翻訳。"},
+		"example.com/api.Title":   {Title: "タイトル"},
+	}
+
+	tests := []struct {
+		Name     string
+		Key      string
+		Comments []string
+		Expected template.HTML
+		Issue    string
+	}{
+		{Name: "up to date", Key: "example.com/api.Current", Comments: current, Expected: "Foo は例です。"},
+		{Name: "stale", Key: "example.com/api.Stale", Comments: current, Expected: "古い翻訳。", Issue: IssueStale},
+		{Name: "untranslated", Key: "example.com/api.Missing", Comments: current, Expected: "Foo is an example.", Issue: IssueUntranslated},
+		{Name: "title only", Key: "example.com/api.Title", Comments: current, Expected: "Foo is an example.", Issue: IssueUntranslated},
+		{Name: "no comment", Key: "example.com/api.Empty", Comments: []string{"+optional"}, Expected: ""},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			g := &Generator{config: Config{MarkdownDisabled: true}, overlay: overlay, translations: newTranslationReport()}
+			idx := newTypeIndex(g, "kubeadm")
+			if actual := idx.translatedComment(test.Key, test.Comments); actual != test.Expected {
+				t.Errorf("Expected %q, got %q", test.Expected, actual)
+			}
+			issues := g.TranslationIssues()
+			if test.Issue == "" {
+				if len(issues) > 0 {
+					t.Errorf("Expected no issue, got %v", issues)
+				}
+				return
+			}
+			expected := []TranslationIssue{{Kind: test.Issue, Key: test.Key, Hash: hash, Source: "Foo is an example.", APIs: []string{"kubeadm"}}}
+			if !reflect.DeepEqual(issues, expected) {
+				t.Errorf("Expected issues %v, got %v", expected, issues)
+			}
+		})
+	}
+}
+
+func TestTranslatedCommentWithoutOverlay(t *testing.T) {
+	g := &Generator{config: Config{MarkdownDisabled: true}}
+	idx := newTypeIndex(g, "kubeadm")
+	if actual := idx.translatedComment("example.com/api.Foo", []string{"Foo is an example."}); actual != "Foo is an example." {
+		t.Errorf("Expected the source comment, got %q", actual)
+	}
+	if issues := g.TranslationIssues(); issues != nil {
+		t.Errorf("Expected no issue without a language, got %v", issues)
+	}
+}
+
+func TestTranslationReport(t *testing.T) {
+	r := newTranslationReport()
+	r.add(IssueStale, "example.com/api.Foo", "abc", "Foo.", "kubelet")
+	r.add(IssueStale, "example.com/api.Foo", "abc", "Foo.", "kubeadm")
+	r.add(IssueStale, "example.com/api.Foo", "abc", "Foo.", "kubeadm")
+	r.add(IssueUntranslated, "example.com/api.Bar", "def", "Bar.", "kubeadm")
+
+	expected := []TranslationIssue{
+		{Kind: IssueUntranslated, Key: "example.com/api.Bar", Hash: "def", Source: "Bar.", APIs: []string{"kubeadm"}},
+		{Kind: IssueStale, Key: "example.com/api.Foo", Hash: "abc", Source: "Foo.", APIs: []string{"kubeadm", "kubelet"}},
+	}
+	if actual := r.list(); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Expected issues %v, got %v", expected, actual)
+	}
+}
+
+func TestTranslatedTitle(t *testing.T) {
+	g := &Generator{overlay: map[string]translation{
+		"example.com/api":   {Title: "API（日本語）"},
+		"example.com/other": {Comment: "説明。"},
+	}}
+	idx := newTypeIndex(g, "kubeadm")
+	tests := []struct {
+		Key, Title, Expected string
+	}{
+		{"example.com/api", "API", "API（日本語）"},
+		{"example.com/api", "", ""},
+		{"example.com/other", "Other", "Other"},
+		{"example.com/missing", "Missing", "Missing"},
+	}
+	for _, test := range tests {
+		if actual := idx.translatedTitle(test.Key, test.Title); actual != test.Expected {
+			t.Errorf("translatedTitle(%q, %q): expected %q, got %q", test.Key, test.Title, test.Expected, actual)
+		}
+	}
+}
@@ -1,4 +1,4 @@
-package main
+package generators
 
 import (
 	"bytes"
@@ -9,7 +9,6 @@ import (
 	"regexp"
 	"sort"
 	"strings"
-	texttemplate "text/template"
 	"unicode"
 
 	"github.com/yuin/goldmark"
@@ -39,6 +38,8 @@ type apiPackage struct {
 
 	// Resources is the customized resource type names
 	Resources []string
+
+	index *typeIndex
 }
 
 // DisplayName returns the full name of the API package
@@ -72,12 +73,24 @@ func (p *apiPackage) VisibleTypes() []*apiType {
 // GetComment returns the rendered HTML format of the package comment.
 func (p *apiPackage) GetComment() template.HTML {
 	comments := p.GoPackages[0].DocComments
-	return renderComments(comments)
+	return p.index.translatedComment(p.GoPackages[0].Path, comments)
 }
 
 // apiMember is a wrapper of types.Member
 type apiMember struct {
 	types.Member
+
+	// Constraints is the defaulting and validation markers of the member,
+	// or nil if there are none.
+	Constraints *fieldConstraints
+
+	// parent is the type the member belongs to
+	parent *apiType
+
+	// unionStart is the union starting with the member, see GroupedMembers
+	unionStart *apiUnion
+
+	index *typeIndex
 }
 
 // IsOptional tests if the apiMember is an optional one.
@@ -100,7 +113,7 @@ func (m *apiMember) FieldName() string {
 
 // GetType translates the Type field of an apiMember to an apiType reference
 func (m *apiMember) GetType() *apiType {
-	return &apiType{*m.Type}
+	return &apiType{Type: *m.Type, index: m.index}
 }
 
 // Test if a field is an inline one
@@ -110,7 +123,13 @@ func (m *apiMember) IsInline() bool {
 
 // Test if a member is supposed to be hidden.
 func (m *apiMember) Hidden() bool {
-	for _, v := range config.HiddenMemberFields {
+	if hidden, ok := m.visibility(); ok {
+		return hidden
+	}
+	if m.index.opts.HideDeprecated && m.Deprecated() {
+		return true
+	}
+	for _, v := range m.index.config.HiddenMemberFields {
 		if m.Name == v {
 			return true
 		}
@@ -120,12 +139,22 @@ func (m *apiMember) Hidden() bool {
 
 // GetComment returns the rendered HTML output from the field comment.
 func (m *apiMember) GetComment() template.HTML {
-	return renderComments(m.CommentLines)
+	if m.parent == nil {
+		return m.index.renderComments(m.CommentLines)
+	}
+	return m.index.translatedComment(m.parent.Name.String()+"."+m.Name, m.CommentLines)
 }
 
 // apiType is a wrapper of type.Type
 type apiType struct {
 	types.Type
+
+	index *typeIndex
+}
+
+// wrap returns the apiType for a type related to t, e.g. its element type.
+func (t *apiType) wrap(u *types.Type) *apiType {
+	return &apiType{Type: *u, index: t.index}
 }
 
 // isLocal tests if the type should be treated as a local definition
@@ -134,13 +163,16 @@ func (t *apiType) isLocal() bool {
 	if t.Kind == types.Builtin {
 		return false
 	}
-	_, ok := typePkgMap[t.String()]
+	_, ok := t.index.typePkgMap[t.String()]
 	return ok
 }
 
 // isHidden tests if a type is supposed to be hidden.
 func (t *apiType) isHidden() bool {
-	for _, pattern := range config.HideTypePatterns {
+	if hidden, ok := t.visibility(); ok {
+		return hidden
+	}
+	for _, pattern := range t.index.config.HideTypePatterns {
 		if regexp.MustCompile(pattern).MatchString(t.Name.String()) {
 			return true
 		}
@@ -161,7 +193,7 @@ func (t *apiType) typeId() string {
 // deref returns the underlying type when t is a pointer, map, or slice.
 func (t *apiType) deref() *apiType {
 	if t.Elem != nil {
-		return &apiType{*t.Elem}
+		return t.wrap(t.Elem)
 	}
 	return t
 }
@@ -170,12 +202,38 @@ func (t *apiType) deref() *apiType {
 func (t *apiType) GetMembers() []*apiMember {
 	var result []*apiMember
 	for _, m := range t.Members {
-		member := &apiMember{m}
+		member := &apiMember{
+			Member:      m,
+			Constraints: parseConstraints(m.CommentLines),
+			parent:      t,
+			index:       t.index,
+		}
+		// Defaults from markers take precedence over the ones found in
+		// the defaulting functions.
+		if v, ok := t.index.defaultValues[t.Name.String()+"."+m.Name]; ok {
+			if member.Constraints == nil {
+				member.Constraints = &fieldConstraints{}
+			}
+			if member.Constraints.Default == "" {
+				member.Constraints.Default = v
+			}
+		}
 		result = append(result, member)
 	}
 	return result
 }
 
+// HasConstraints tests if any visible member of the type has defaulting or
+// validation markers.
+func (t *apiType) HasConstraints() bool {
+	for _, m := range t.GetMembers() {
+		if !m.Hidden() && m.Constraints != nil {
+			return true
+		}
+	}
+	return false
+}
+
 // IsExported tests if a type is exported
 func (t *apiType) IsExported() bool {
 	comments := strings.Join(t.SecondClosestCommentLines, "\n")
@@ -199,7 +257,7 @@ func (t *apiType) IsExported() bool {
 
 	t1 := t.deref()
 
-	p := typePkgMap[t1.String()]
+	p := t.index.typePkgMap[t1.String()]
 	for _, res := range p.Resources {
 		if res == t.Name.Name {
 			return true
@@ -211,7 +269,7 @@ func (t *apiType) IsExported() bool {
 // Referenced tests if the API type is referenced anywhere in the package
 func (t *apiType) Referenced() bool {
 	typeName := t.String()
-	_, found := references[typeName]
+	_, found := t.index.references[typeName]
 	return found
 }
 
@@ -219,7 +277,7 @@ func (t *apiType) Referenced() bool {
 func (t *apiType) APIGroup() string {
 	t = t.deref()
 
-	p := typePkgMap[t.String()]
+	p := t.index.typePkgMap[t.String()]
 	if p == nil {
 		klog.Warningf("Cannot read apiVersion for %s from type=>pkg map", t.Name.String())
 		return "<UNKNOWN_API_GROUP>"
@@ -254,14 +312,6 @@ func (t *apiType) Link() string {
 		return "#" + t.Anchor()
 	}
 
-	var arrIndex = func(a []string, i int) string {
-		s := a[(len(a)+i)%len(a)]
-		if s == "authentication" {
-			s = "authentication-k8s-io"
-		}
-		return s
-	}
-
 	// types like k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta,
 	// k8s.io/api/core/v1.Container, k8s.io/api/autoscaling/v1.CrossVersionObjectReference,
 	// github.com/knative/build/pkg/apis/build/v1alpha1.BuildSpec
@@ -271,32 +321,31 @@ func (t *apiType) Link() string {
 		// to parse [meta, v1] from "k8s.io/apimachinery/pkg/apis/meta/v1"
 		segments := strings.Split(t.Name.Package, "/")
 
-		for _, v := range config.ExternalPackages {
-			r, err := regexp.Compile(v.Match)
-			if err != nil {
-				klog.Errorf("Pattern %q failed to compile: %+v", v.Match, err)
-				return ""
+		for _, rule := range t.index.linkRules {
+			if rule.matchErr != nil {
+				// reported when the rules are compiled
+				continue
 			}
 			// The type identifier is identified as a type from an "external" package
-			if r.MatchString(id) {
-				tpl, err := texttemplate.New("").Funcs(map[string]interface{}{
-					"lower":    strings.ToLower,
-					"arrIndex": arrIndex,
-				}).Parse(v.Target)
-
-				if err != nil {
-					klog.Errorf("Failed to parse the 'target': %s", v.Target)
+			if rule.match.MatchString(id) {
+				if rule.targetErr != nil {
+					// reported when the rules are compiled
 					return ""
 				}
 
 				var b bytes.Buffer
-				err = tpl.Execute(&b, map[string]interface{}{
+				data := map[string]interface{}{
 					"TypeIdentifier":  t.Name.Name,
 					"PackagePath":     t.Name.Package,
 					"PackageSegments": segments,
-				})
+				}
+				for k, v := range t.index.linkVars {
+					data[k] = v
+				}
+				err := rule.target.Execute(&b, data)
 				if err != nil {
 					klog.Errorf("Failed to execute template: %+v", err)
+					t.index.links.add(IssueTemplate, id, rule.Match, t.index.api, err.Error())
 					return ""
 				}
 				return b.String()
@@ -306,13 +355,14 @@ func (t *apiType) Link() string {
 		// We are here if the type identifier for the type is not listed as an
 		// external one. This means we have to parse it.
 		klog.Errorf("External link source for '%s.%s' is not found.", t.Name.Package, t.Name.Name)
+		t.index.links.add(IssueUnresolved, id, "", t.index.api, "")
 	}
 	return ""
 }
 
-func stripPrefix(s string) string {
+func (idx *typeIndex) stripPrefix(s string) string {
 	// strip prefix if desired
-	for _, prefix := range config.StripPrefix {
+	for _, prefix := range idx.config.StripPrefix {
 		if strings.HasPrefix(s, prefix) {
 			s = strings.Replace(s, prefix, "", 1)
 		}
@@ -339,13 +389,13 @@ func (t *apiType) DisplayName() string {
 		types.Builtin:
 		// noop
 	case types.Map:
-		elm := apiType{*t.Elem}
-		return strings.Join([]string{"map[", stripPrefix(t.Key.Name.Name), "]", elm.DisplayName()}, "")
+		elm := t.wrap(t.Elem)
+		return strings.Join([]string{"map[", t.index.stripPrefix(t.Key.Name.Name), "]", elm.DisplayName()}, "")
 	default:
 		klog.Warningf("Type '%s' has kind='%v' which is unhandled", t.Name, t.Kind)
 	}
 
-	s = stripPrefix(s)
+	s = t.index.stripPrefix(s)
 
 	if t.Kind == types.Slice {
 		s = "[]" + s
@@ -356,14 +406,14 @@ func (t *apiType) DisplayName() string {
 
 // GetComment returns the rendered comment doc for the type.
 func (t *apiType) GetComment() template.HTML {
-	return renderComments(t.CommentLines)
+	return t.index.translatedComment(t.Name.String(), t.CommentLines)
 }
 
 // References returns a list of types where the current type is referenced.
 func (t *apiType) References() []*apiType {
 	var out []*apiType
 	m := make(map[*apiType]struct{})
-	for _, ref := range references[t.String()] {
+	for _, ref := range t.index.references[t.String()] {
 		if !ref.isHidden() {
 			m[ref] = struct{}{}
 		}
@@ -423,7 +473,7 @@ func sortTypes(typs []*apiType) []*apiType {
 
 // renderComments is a utility function for processing a list of strings into
 // safe and valid HTML snippets.
-func renderComments(comments []string) template.HTML {
+func (idx *typeIndex) renderComments(comments []string) template.HTML {
 	var res string
 	// filter out tags in comments
 	var list []string
@@ -436,7 +486,7 @@ func renderComments(comments []string) template.HTML {
 
 	// replace '*' by '&lowast;', we do this before parsing the comment as markdown
 	// doc = strings.ReplaceAll(doc, "*", "\\*")
-	if !config.MarkdownDisabled {
+	if !idx.config.MarkdownDisabled {
 		// This is for blackfriday
 		// res = string(blackfriday.Run([]byte(doc)))
 		var buf bytes.Buffer
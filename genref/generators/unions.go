@@ -0,0 +1,147 @@
+package generators
+
+import (
+	"regexp"
+	"strings"
+
+	"k8s.io/gengo/types"
+)
+
+// apiUnion is a group of mutually exclusive members of a type.
+type apiUnion struct {
+	// Members are the members of the union, in the order of declaration
+	Members []*apiMember
+
+	// Discriminator is the serialized name of the member telling which
+	// member is set, if any.
+	Discriminator string
+
+	// Exactly is set if one member must be set, rather than at most one.
+	Exactly bool
+}
+
+// exactlyOnePattern matches a sentence like "Exactly one of `foo` or `bar`
+// must be specified".
+var exactlyOnePattern = regexp.MustCompile(`(?i)\bexactly one of\b([^.]*)`)
+
+// wordPattern matches the words of an "exactly one of" sentence, the same as
+// between word boundaries.
+var wordPattern = regexp.MustCompile(`\w+`)
+
+// FieldNames returns the serialized names of the union members.
+func (u *apiUnion) FieldNames() []string {
+	var names []string
+	for _, m := range u.Members {
+		names = append(names, m.FieldName())
+	}
+	return names
+}
+
+// hasMarker tests if any of the markers is found in the comment lines.
+func hasMarker(comments []string, names ...string) bool {
+	tags := types.ExtractCommentTags("+", comments)
+	for _, n := range names {
+		if _, ok := tags[n]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// unions finds the unions among the visible members of the type, from the
+// '+union', '+unionDiscriminator' and '+unionMember' markers, and from the
+// "exactly one of" sentences in the type comment. The union members of a
+// type with a '+union' marker are the ones with a '+unionMember' marker if
+// any, or all the optional members but the discriminator otherwise.
+func (t *apiType) unions(members []*apiMember) []*apiUnion {
+	var visible []*apiMember
+	for _, m := range members {
+		if !m.Hidden() && !m.IsInline() {
+			visible = append(visible, m)
+		}
+	}
+
+	var result []*apiUnion
+	inUnion := map[*apiMember]bool{}
+
+	u := &apiUnion{}
+	var explicit, optional []*apiMember
+	for _, m := range visible {
+		switch {
+		case hasMarker(m.CommentLines, "unionDiscriminator", "k8s:unionDiscriminator"):
+			if u.Discriminator == "" {
+				u.Discriminator = m.FieldName()
+			}
+		case hasMarker(m.CommentLines, "unionMember", "k8s:unionMember"):
+			explicit = append(explicit, m)
+		case m.IsOptional():
+			optional = append(optional, m)
+		}
+	}
+	u.Members = explicit
+	if len(explicit) == 0 && hasMarker(append(t.SecondClosestCommentLines, t.CommentLines...), "union") {
+		u.Members = optional
+	}
+	if len(u.Members) > 1 {
+		result = append(result, u)
+		for _, m := range u.Members {
+			inUnion[m] = true
+		}
+	}
+
+	comments := strings.Join(t.CommentLines, " ")
+	for _, match := range exactlyOnePattern.FindAllStringSubmatch(comments, -1) {
+		words := map[string]bool{}
+		for _, w := range wordPattern.FindAllString(match[1], -1) {
+			words[w] = true
+		}
+		u := &apiUnion{Exactly: true}
+		for _, m := range visible {
+			if !inUnion[m] && (words[m.FieldName()] || words[m.Name]) {
+				u.Members = append(u.Members, m)
+			}
+		}
+		if len(u.Members) > 1 {
+			result = append(result, u)
+			for _, m := range u.Members {
+				inUnion[m] = true
+			}
+		}
+	}
+	return result
+}
+
+// GroupedMembers returns the members of the type like GetMembers, except that
+// the members of a union are moved next to the first one of them. The first
+// member of each union is marked with the union, see UnionStart.
+func (t *apiType) GroupedMembers() []*apiMember {
+	members := t.GetMembers()
+	unions := t.unions(members)
+	if len(unions) == 0 {
+		return members
+	}
+
+	unionOf := map[*apiMember]*apiUnion{}
+	for _, u := range unions {
+		u.Members[0].unionStart = u
+		for _, m := range u.Members {
+			unionOf[m] = u
+		}
+	}
+	var result []*apiMember
+	for _, m := range members {
+		u, ok := unionOf[m]
+		if !ok {
+			result = append(result, m)
+		} else if m.unionStart != nil {
+			result = append(result, u.Members...)
+		}
+	}
+	return result
+}
+
+// UnionStart returns the union starting with the member in the result of
+// GroupedMembers, or nil.
+func (m *apiMember) UnionStart() *apiUnion {
+	return m.unionStart
+}
@@ -0,0 +1,125 @@
+package generators
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/gengo/types"
+)
+
+// withComments returns the member with the given comment lines added.
+func withComments(m types.Member, comments ...string) types.Member {
+	m.CommentLines = append(m.CommentLines, comments...)
+	return m
+}
+
+func TestUnions(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Comments []string
+		Members  []types.Member
+		Expected [][]string
+	}{
+		{
+			Name:     "exactly one of",
+			Comments: []string{"Source is a volume source.", "Exactly one of `configMap` or `secret` must be specified."},
+			Members: []types.Member{
+				testMember("Name", "name", types.String, false),
+				testMember("ConfigMap", "configMap", types.String, true),
+				testMember("Secret", "secret", types.String, true),
+			},
+			Expected: [][]string{{"configMap", "secret"}},
+		},
+		{
+			Name:     "go names across lines",
+			Comments: []string{"Exactly one of Exec, HTTPGet", "or TCPSocket must be set."},
+			Members: []types.Member{
+				testMember("Exec", "exec", types.String, true),
+				testMember("HTTPGet", "httpGet", types.String, true),
+				testMember("TCPSocket", "tcpSocket", types.String, true),
+			},
+			Expected: [][]string{{"exec", "httpGet", "tcpSocket"}},
+		},
+		{
+			Name:     "whole words only",
+			Comments: []string{"Exactly one of portName or hostPort must be set."},
+			Members: []types.Member{
+				testMember("Port", "port", types.Int32, true),
+				testMember("PortName", "portName", types.String, true),
+				testMember("Host", "host", types.String, true),
+			},
+			Expected: nil,
+		},
+		{
+			Name:     "single member",
+			Comments: []string{"Exactly one of `secret` must be set."},
+			Members:  []types.Member{testMember("Secret", "secret", types.String, true)},
+			Expected: nil,
+		},
+		{
+			Name:     "union marker with a discriminator",
+			Comments: []string{"+union"},
+			Members: []types.Member{
+				withComments(testMember("Type", "type", types.String, false), "+unionDiscriminator"),
+				testMember("Fixed", "fixed", types.Int32, true),
+				testMember("Percent", "percent", types.Int32, true),
+			},
+			Expected: [][]string{{"fixed", "percent"}},
+		},
+		{
+			Name: "union members",
+			Members: []types.Member{
+				withComments(testMember("A", "a", types.String, false), "+unionMember"),
+				testMember("B", "b", types.String, true),
+				withComments(testMember("C", "c", types.String, false), "+k8s:unionMember"),
+			},
+			Expected: [][]string{{"a", "c"}},
+		},
+		{
+			Name:     "members are not in several unions",
+			Comments: []string{"+union", "Exactly one of a or b must be set. Exactly one of b or c must be set."},
+			Members: []types.Member{
+				testMember("A", "a", types.String, true),
+				testMember("B", "b", types.String, true),
+				testMember("C", "c", types.String, false),
+			},
+			Expected: [][]string{{"a", "b"}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			typ := testType("Source", test.Members...)
+			typ.CommentLines = test.Comments
+			var result [][]string
+			for _, u := range typ.unions(typ.GetMembers()) {
+				result = append(result, u.FieldNames())
+			}
+			if !reflect.DeepEqual(result, test.Expected) {
+				t.Errorf("Expected %v, got %v", test.Expected, result)
+			}
+		})
+	}
+}
+
+func TestGroupedMembers(t *testing.T) {
+	typ := testType("Source",
+		testMember("ConfigMap", "configMap", types.String, true),
+		testMember("Name", "name", types.String, false),
+		testMember("Secret", "secret", types.String, true),
+	)
+	typ.CommentLines = []string{"Exactly one of configMap or secret must be set."}
+
+	var names []string
+	var starts []bool
+	for _, m := range typ.GroupedMembers() {
+		names = append(names, m.FieldName())
+		starts = append(starts, m.UnionStart() != nil)
+	}
+	if expected := []string{"configMap", "secret", "name"}; !reflect.DeepEqual(names, expected) {
+		t.Errorf("Expected %v, got %v", expected, names)
+	}
+	if expected := []bool{true, false, false}; !reflect.DeepEqual(starts, expected) {
+		t.Errorf("Expected union starts %v, got %v", expected, starts)
+	}
+}
@@ -0,0 +1,102 @@
+package generators
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// Actions of the visibility rules
+const (
+	// ActionInclude shows the matching types or fields, even if they are
+	// hidden otherwise.
+	ActionInclude = "include"
+	// ActionExclude hides the matching types or fields.
+	ActionExclude = "exclude"
+)
+
+// visibilityRule is a VisibilityRule with its selectors compiled.
+type visibilityRule struct {
+	VisibilityRule
+
+	pkg   *regexp.Regexp
+	typ   *regexp.Regexp
+	field *regexp.Regexp
+}
+
+// compileVisibilityRules validates and compiles the visibility rules in the
+// configuration.
+func compileVisibilityRules(rules []VisibilityRule) ([]*visibilityRule, error) {
+	var result []*visibilityRule
+	for i, v := range rules {
+		if v.Action != ActionInclude && v.Action != ActionExclude {
+			return nil, fmt.Errorf("visibility rule #%d: invalid action %q, expecting '%s' or '%s'", i+1, v.Action, ActionInclude, ActionExclude)
+		}
+		r := &visibilityRule{VisibilityRule: v}
+		var err error
+		if r.pkg, err = compileSelector(v.Package); err != nil {
+			return nil, fmt.Errorf("visibility rule #%d: invalid package selector: %w", i+1, err)
+		}
+		if r.typ, err = compileSelector(v.Type); err != nil {
+			return nil, fmt.Errorf("visibility rule #%d: invalid type selector: %w", i+1, err)
+		}
+		if r.field, err = compileSelector(v.Field); err != nil {
+			return nil, fmt.Errorf("visibility rule #%d: invalid field selector: %w", i+1, err)
+		}
+		result = append(result, r)
+	}
+	return result, nil
+}
+
+// compileSelector compiles a selector matching a whole name. An empty
+// selector matches any name.
+func compileSelector(s string) (*regexp.Regexp, error) {
+	if s == "" {
+		return nil, nil
+	}
+	return regexp.Compile("^(?:" + s + ")$")
+}
+
+// matchType tests if the rule selects the type, ignoring the field selector.
+func (r *visibilityRule) matchType(t *apiType) bool {
+	if r.API != "" && r.API != t.index.api {
+		return false
+	}
+	if r.FromIncludes && !containsString(t.index.includes, t.Name.Package) {
+		return false
+	}
+	if r.pkg != nil && !r.pkg.MatchString(t.Name.Package) {
+		return false
+	}
+	return r.typ == nil || r.typ.MatchString(t.Name.Name)
+}
+
+// visibility returns whether the type is hidden by the last rule without a
+// field selector that matches it. The second result is false if no rule
+// matches.
+func (t *apiType) visibility() (hidden bool, matched bool) {
+	for _, r := range t.index.visibilityRules {
+		if r.field == nil && r.matchType(t) {
+			hidden, matched = r.Action == ActionExclude, true
+		}
+	}
+	return hidden, matched
+}
+
+// visibility returns whether the member is hidden by the last rule with a
+// field selector that matches it. The field selector matches either the Go
+// name or the serialized name of the member. The second result is false if
+// no rule matches.
+func (m *apiMember) visibility() (hidden bool, matched bool) {
+	if m.parent == nil {
+		return false, false
+	}
+	for _, r := range m.index.visibilityRules {
+		if r.field == nil || !r.matchType(m.parent) {
+			continue
+		}
+		if r.field.MatchString(m.Name) || r.field.MatchString(m.FieldName()) {
+			hidden, matched = r.Action == ActionExclude, true
+		}
+	}
+	return hidden, matched
+}
@@ -0,0 +1,232 @@
+package generators
+
+import (
+	"testing"
+
+	"k8s.io/gengo/types"
+)
+
+// testVisibilityIndex returns the type index of the 'kubeadm' API definition
+// with the given rules, hiding the 'Internal.*' types and the 'TypeMeta'
+// fields globally.
+func testVisibilityIndex(t *testing.T, rules ...VisibilityRule) *typeIndex {
+	t.Helper()
+	compiled, err := compileVisibilityRules(rules)
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := &Generator{
+		config: Config{
+			HideTypePatterns:   []string{`\.Internal.*$`},
+			HiddenMemberFields: []string{"TypeMeta"},
+		},
+		visibilityRules: compiled,
+	}
+	idx := newTypeIndex(g, "kubeadm")
+	idx.includes = []string{"example.com/shared"}
+	return idx
+}
+
+// visibilityType returns a type of the given package with a 'TypeMeta' and a
+// 'Bar' member, registered in the index.
+func visibilityType(idx *typeIndex, pkg, name string) *apiType {
+	t := &apiType{
+		Type: types.Type{
+			Name: types.Name{Package: pkg, Name: name},
+			Kind: types.Struct,
+			Members: []types.Member{
+				{Name: "TypeMeta", Type: types.String, Embedded: true, Tags: `json:",inline"`},
+				testMember("Bar", "bar", types.String, true),
+			},
+		},
+		index: idx,
+	}
+	idx.typePkgMap[t.String()] = &apiPackage{apiVersion: "v1", index: idx}
+	return t
+}
+
+func TestCompileVisibilityRules(t *testing.T) {
+	tests := []struct {
+		Name  string
+		Rule  VisibilityRule
+		Error bool
+	}{
+		{Name: "valid rule", Rule: VisibilityRule{Type: "Foo", Action: ActionExclude}},
+		{Name: "invalid action", Rule: VisibilityRule{Type: "Foo", Action: "hide"}, Error: true},
+		{Name: "invalid package selector", Rule: VisibilityRule{Package: "(", Action: ActionExclude}, Error: true},
+		{Name: "invalid type selector", Rule: VisibilityRule{Type: "[", Action: ActionInclude}, Error: true},
+		{Name: "invalid field selector", Rule: VisibilityRule{Field: "(", Action: ActionExclude}, Error: true},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			_, err := compileVisibilityRules([]VisibilityRule{test.Rule})
+			if test.Error && err == nil {
+				t.Errorf("Expected an error")
+			} else if !test.Error && err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestTypeVisibilityRules(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Rules    []VisibilityRule
+		Package  string
+		Type     string
+		Expected bool
+	}{
+		{Name: "no rule", Package: "example.com/api", Type: "Foo", Expected: false},
+		{Name: "global pattern", Package: "example.com/api", Type: "InternalFoo", Expected: true},
+		{
+			Name:     "include wins over the global pattern",
+			Rules:    []VisibilityRule{{Type: "InternalFoo", Action: ActionInclude}},
+			Package:  "example.com/api",
+			Type:     "InternalFoo",
+			Expected: false,
+		},
+		{
+			Name: "last rule wins",
+			Rules: []VisibilityRule{
+				{Type: "Foo.*", Action: ActionExclude},
+				{Type: "FooList", Action: ActionInclude},
+			},
+			Package:  "example.com/api",
+			Type:     "FooList",
+			Expected: false,
+		},
+		{
+			Name: "earlier rule is overridden",
+			Rules: []VisibilityRule{
+				{Type: "FooList", Action: ActionInclude},
+				{Type: "Foo.*", Action: ActionExclude},
+			},
+			Package:  "example.com/api",
+			Type:     "FooList",
+			Expected: true,
+		},
+		{
+			Name:     "selector matches the whole name",
+			Rules:    []VisibilityRule{{Type: "Foo", Action: ActionExclude}},
+			Package:  "example.com/api",
+			Type:     "FooList",
+			Expected: false,
+		},
+		{
+			Name:     "rule of another API",
+			Rules:    []VisibilityRule{{API: "kubelet-config", Type: "Foo", Action: ActionExclude}},
+			Package:  "example.com/api",
+			Type:     "Foo",
+			Expected: false,
+		},
+		{
+			Name:     "rule of the API",
+			Rules:    []VisibilityRule{{API: "kubeadm", Type: "Foo", Action: ActionExclude}},
+			Package:  "example.com/api",
+			Type:     "Foo",
+			Expected: true,
+		},
+		{
+			Name:     "included package",
+			Rules:    []VisibilityRule{{FromIncludes: true, Type: "Foo", Action: ActionExclude}},
+			Package:  "example.com/shared",
+			Type:     "Foo",
+			Expected: true,
+		},
+		{
+			Name:     "package not included",
+			Rules:    []VisibilityRule{{FromIncludes: true, Type: "Foo", Action: ActionExclude}},
+			Package:  "example.com/api",
+			Type:     "Foo",
+			Expected: false,
+		},
+		{
+			Name:     "package selector",
+			Rules:    []VisibilityRule{{Package: `example\.com/.*`, Action: ActionExclude}},
+			Package:  "example.com/api",
+			Type:     "Foo",
+			Expected: true,
+		},
+		{
+			Name:     "field rule does not apply to the type",
+			Rules:    []VisibilityRule{{Type: "Foo", Field: "bar", Action: ActionExclude}},
+			Package:  "example.com/api",
+			Type:     "Foo",
+			Expected: false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			idx := testVisibilityIndex(t, test.Rules...)
+			if hidden := visibilityType(idx, test.Package, test.Type).isHidden(); hidden != test.Expected {
+				t.Errorf("Expected hidden %v, got %v", test.Expected, hidden)
+			}
+		})
+	}
+}
+
+func TestMemberVisibilityRules(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Rules    []VisibilityRule
+		Type     string
+		Member   string
+		Expected bool
+	}{
+		{Name: "no rule", Type: "Foo", Member: "Bar", Expected: false},
+		{Name: "global field", Type: "Foo", Member: "TypeMeta", Expected: true},
+		{
+			Name:     "include wins over the global field",
+			Rules:    []VisibilityRule{{Type: "Foo", Field: "TypeMeta", Action: ActionInclude}},
+			Type:     "Foo",
+			Member:   "TypeMeta",
+			Expected: false,
+		},
+		{
+			Name:     "serialized name",
+			Rules:    []VisibilityRule{{Type: "Foo", Field: "bar", Action: ActionExclude}},
+			Type:     "Foo",
+			Member:   "Bar",
+			Expected: true,
+		},
+		{
+			Name:     "field of another type",
+			Rules:    []VisibilityRule{{Type: "Foo", Field: "bar", Action: ActionExclude}},
+			Type:     "Other",
+			Member:   "Bar",
+			Expected: false,
+		},
+		{
+			Name: "last rule wins",
+			Rules: []VisibilityRule{
+				{Field: "Bar", Action: ActionExclude},
+				{Type: "Foo", Field: "bar", Action: ActionInclude},
+			},
+			Type:     "Foo",
+			Member:   "Bar",
+			Expected: false,
+		},
+		{
+			Name:     "type rule does not apply to the fields",
+			Rules:    []VisibilityRule{{Type: "Foo", Action: ActionExclude}},
+			Type:     "Foo",
+			Member:   "Bar",
+			Expected: false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			idx := testVisibilityIndex(t, test.Rules...)
+			var member *apiMember
+			for _, m := range visibilityType(idx, "example.com/api", test.Type).GetMembers() {
+				if m.Name == test.Member {
+					member = m
+				}
+			}
+			if hidden := member.Hidden(); hidden != test.Expected {
+				t.Errorf("Expected hidden %v, got %v", test.Expected, hidden)
+			}
+		})
+	}
+}
@@ -0,0 +1,26 @@
+{{ define "index" }}
+  <html lang="en">
+    <head>
+      <meta charset="utf-8">
+      <link rel="stylesheet" href="https://maxcdn.bootstrapcdn.com/bootstrap/4.3.1/css/bootstrap.min.css">
+      <title>Configuration APIs</title>
+    </head>
+    <body>
+      <div class="container">
+        <H1>Configuration APIs</H1>
+        {{ range .apis }}
+          <H2>{{ .Title }}</H2>
+          <ul>
+            {{ range .Versions }}
+              <li><a href="{{ .Link }}"><code>{{ .Version }}</code></a>{{ with .Stability }} ({{ . }}){{ end }}</li>
+            {{ end }}
+          </ul>
+        {{ end }}
+      </div>
+
+      <div class="container">
+        <p><em>Generated with <code>genref</code>{{ with .gitCommit }} on git commit <code>{{ . }}</code>{{end}}</em></p>
+      </div>
+    </body>
+  </html>
+{{ end }}
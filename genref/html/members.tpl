@@ -1,8 +1,22 @@
 {{ define "members" }}
 
   {{/* . is a apiType */}}
-  {{ range .GetMembers }}
+  {{ $withConstraints := .HasConstraints }}
+  {{ range .GroupedMembers }}
     {{/* . is a apiMember */}}
+    {{ with .UnionStart }}
+      <tr>
+        <td colspan="{{ if $withConstraints }}3{{ else }}2{{ end }}">
+          <em>
+            The fields
+            {{ range $i, $n := .FieldNames }}{{ if $i }},{{ end }} <code>{{ $n }}</code>{{ end }}
+            are mutually exclusive:
+            {{ if .Exactly }}exactly one of them must be set{{ else }}at most one of them can be set{{ end }}{{ with .Discriminator }},
+            as indicated by <code>{{ . }}</code>{{ end }}.
+          </em>
+        </td>
+      </tr>
+    {{ end }}
     {{ if not .Hidden }}
       <tr>
         <td><code>{{ .FieldName }}</code>
@@ -22,13 +36,30 @@
             {{ end }}
           {{ end }}
         </td>
-        <td>
+        {{/* the description spans the constraints column of a field without constraints */}}
+        <td{{ if and $withConstraints (not .Constraints.Items) }} colspan="2"{{ end }}>
+          {{ if or .Deprecated .FeatureGate .AddedIn .RemovedIn }}
+            <p>{{ template "badges" . }}</p>
+          {{ end }}
+
           {{ if .IsInline }}
             <p>(Members of <code>{{ .FieldName }}</code> are embedded into this type.)</p>
           {{ end}}
 
           {{ .GetComment }}
 
+          {{ with .GetType.AllowedValues }}
+            <p>Allowed values:</p>
+            <table>
+              {{ range . }}
+                <tr>
+                  <td><code>{{ with .Value }}{{ . }}{{ else }}""{{ end }}</code></td>
+                  <td>{{ .GetComment }}</td>
+                </tr>
+              {{ end }}
+            </table>
+          {{ end }}
+
           {{ if and (eq (.GetType.Name.Name) "ObjectMeta") }}
             Refer to the Kubernetes API documentation for the fields of the
             <code>metadata</code> field.
@@ -42,6 +73,14 @@
             </table>
           {{ end }}
         </td>
+        {{ if and $withConstraints .Constraints.Items }}
+          <td>
+            {{ range $i, $c := .Constraints.Items }}
+              {{ if $i }}<br/>{{ end }}
+              {{ $c.Name }}: <code>{{ $c.Value }}</code>
+            {{ end }}
+          </td>
+        {{ end }}
       </tr>
     {{ end }}
   {{ end }}
@@ -0,0 +1,85 @@
+{{ define "migration" }}
+  <html lang="en">
+    <head>
+      <meta charset="utf-8">
+      <link rel="stylesheet" href="https://maxcdn.bootstrapcdn.com/bootstrap/4.3.1/css/bootstrap.min.css">
+      <style type="text/css">
+        code {
+          color: #802060;
+          display: inline-block;
+        }
+      </style>
+    </head>
+    <body>
+      <div class="container">
+        <H2>Migrating {{ .Title }} from {{ .From }} to {{ .To }}</H2>
+        <p>
+          This page lists the changes from <code>{{ .FromAPIVersion }}</code>
+          to <code>{{ .ToAPIVersion }}</code> that need attention when migrating
+          configuration files.
+        </p>
+
+        {{ with .AddedKinds }}
+          <H3>Added Kinds</H3>
+          <ul>
+            {{ range . }}<li><code>{{ . }}</code></li>{{ end }}
+          </ul>
+        {{ end }}
+        {{ with .RemovedKinds }}
+          <H3>Removed Kinds</H3>
+          <ul>
+            {{ range . }}<li><code>{{ . }}</code></li>{{ end }}
+          </ul>
+        {{ end }}
+        {{ with .AddedTypes }}
+          <H3>Added Types</H3>
+          <ul>
+            {{ range . }}<li><code>{{ . }}</code></li>{{ end }}
+          </ul>
+        {{ end }}
+        {{ with .RemovedTypes }}
+          <H3>Removed Types</H3>
+          <ul>
+            {{ range . }}<li><code>{{ . }}</code></li>{{ end }}
+          </ul>
+        {{ end }}
+
+        {{ range .Types }}
+          <H3>{{ .Name }}{{ if .IsKind }} (kind){{ end }}</H3>
+          <table class="table">
+            <thead>
+              <tr>
+                <th>Field</th>
+                <th>Change</th>
+                <th>Details</th>
+              </tr>
+            </thead>
+            <tbody>
+              {{ range .Changes }}
+                <tr>
+                  <td><code>{{ .Field }}</code></td>
+                  <td>{{ .Change }}</td>
+                  <td>
+                    {{ if eq .Change "added" }}
+                      New field of type <code>{{ .To }}</code>.
+                    {{ else if eq .Change "removed" }}
+                      Field of type <code>{{ .From }}</code> is removed.
+                    {{ else if eq .Change "renamed" }}
+                      Renamed from <code>{{ .From }}</code>.
+                    {{ else if eq .Change "retyped" }}
+                      Type changed from <code>{{ .From }}</code> to <code>{{ .To }}</code>.
+                    {{ else }}
+                      Changed from {{ .From }} to {{ .To }}.
+                    {{ end }}
+                  </td>
+                </tr>
+              {{ end }}
+            </tbody>
+          </table>
+        {{ else }}
+          <p>No field changes are found for the types that exist in both versions.</p>
+        {{ end }}
+      </div>
+    </body>
+  </html>
+{{ end }}
@@ -19,14 +19,39 @@
     </p>
   {{ end }}
 
+  {{ if or .Deprecated .FeatureGate .AddedIn .RemovedIn }}
+    <p>{{ template "badges" . }}</p>
+  {{ end }}
+
   <p>{{ .GetComment }}</p>
 
+  {{ with .AllowedValues }}
+    <p><strong>Allowed values:</strong></p>
+    <table class="table">
+      <thead>
+        <tr>
+          <th>Value</th>
+          <th>Description</th>
+        </tr>
+      </thead>
+      <tbody>
+        {{ range . }}
+          <tr>
+            <td><code>{{ with .Value }}{{ . }}{{ else }}""{{ end }}</code></td>
+            <td>{{ .GetComment }}</td>
+          </tr>
+        {{ end }}
+      </tbody>
+    </table>
+  {{ end }}
+
   {{ if .GetMembers }}
     <table class="table">
       <thead>
         <tr>
           <th>Field</th>
           <th>Description</th>
+          {{ if .HasConstraints }}<th>Constraints</th>{{ end }}
         </tr>
       </thead>
       <tbody>
@@ -35,11 +60,11 @@
           {{/* Add apiVersion and kind rows if deemed necessary */}}
           <tr>
             <td><code>apiVersion</code></br>string</td>
-            <td><code>{{ .APIGroup }}</code></td>
+            <td{{ if .HasConstraints }} colspan="2"{{ end }}><code>{{ .APIGroup }}</code></td>
           </tr>
           <tr>
             <td><code>kind</code></br>string</td>
-            <td><code>{{ .Name.Name }}</code></td>
+            <td{{ if .HasConstraints }} colspan="2"{{ end }}><code>{{ .Name.Name }}</code></td>
           </tr>
         {{ end }}
 
@@ -49,4 +74,9 @@
       </tbody>
     </table>
   {{ end }}
+
+  {{ with .Skeleton }}
+    <p><strong>Example:</strong></p>
+    <pre><code class="language-yaml">{{ . }}</code></pre>
+  {{ end }}
 {{ end }}
@@ -0,0 +1,7 @@
+{{ define "badges" -}}
+{{/* . is a apiType or a apiMember */}}
+{{- if .Deprecated }}<span class="badge badge-warning">Deprecated</span> {{ end -}}
+{{- with .AddedIn }}<span class="badge badge-success">Added in {{ . }}</span> {{ end -}}
+{{- with .RemovedIn }}<span class="badge badge-danger">Removed in {{ . }}</span> {{ end -}}
+{{- range .FeatureGate }}<span class="badge badge-info">Feature gate: <code>{{ . }}</code></span> {{ end -}}
+{{- end }}
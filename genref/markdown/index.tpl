@@ -0,0 +1,16 @@
+{{ define "index" -}}
+---
+title: Configuration APIs
+content_type: reference
+auto_generated: true
+---
+
+This page lists the reference documentation of the configuration APIs.
+{{ range .apis }}
+## {{ .Title }}
+
+{{ range .Versions -}}
+- [`{{ .Version }}`]({{ .Link }}){{ with .Stability }} ({{ . }}){{ end }}
+{{ end -}}
+{{ end -}}
+{{ end }}
@@ -1,7 +1,14 @@
 {{ define "members" }}
   {{/* . is a apiType */}}
-  {{- range .GetMembers -}}
+  {{- $withConstraints := .HasConstraints -}}
+  {{- range .GroupedMembers -}}
     {{/* . is a apiMember */}}
+    {{- with .UnionStart }}
+<tr><td colspan="{{ if $withConstraints }}3{{ else }}2{{ end }}"><em>The fields
+{{- range $i, $n := .FieldNames }}{{ if $i }},{{ end }} <code>{{ $n }}</code>{{ end }} are mutually exclusive:
+{{ if .Exactly }}exactly one of them must be set{{ else }}at most one of them can be set{{ end }}
+{{- with .Discriminator }}, as indicated by <code>{{ . }}</code>{{ end }}.</em></td></tr>
+    {{- end }}
     {{- if not .Hidden }}
 <tr><td><code>{{ .FieldName }}</code>
       {{- if not .IsOptional }} <B>[Required]</B>{{- end -}}
@@ -15,7 +22,8 @@
         {{- end -}}
       {{- end }}
 </td>
-<td>
+<td{{ if and $withConstraints (not .Constraints.Items) }} colspan="2"{{ end }}>
+{{- template "badges" . -}}
    {{- if .IsInline -}}
 (Members of <code>{{ .FieldName }}</code> are embedded into this type.)
    {{- end }}
@@ -23,11 +31,27 @@
    {{ .GetComment }}
    {{- else -}}
    <span class="text-muted">No description provided.</span>
+   {{- end }}
+   {{- with .GetType.AllowedValues }}
+<p>Allowed values:</p>
+<table>
+     {{- range . }}
+<tr><td><code>{{ with .Value }}{{ html . }}{{ else }}""{{ end }}</code></td><td>{{ .GetComment }}</td></tr>
+     {{- end }}
+</table>
    {{- end }}
    {{- if and (eq (.GetType.Name.Name) "ObjectMeta") -}}
 Refer to the Kubernetes API documentation for the fields of the <code>metadata</code> field.
    {{- end -}}
 </td>
+      {{- if and $withConstraints .Constraints.Items }}
+<td>
+        {{- range $i, $c := .Constraints.Items -}}
+          {{- if $i }}<br/>{{ end -}}
+{{ $c.Name }}: <code>{{ html $c.Value }}</code>
+        {{- end -}}
+</td>
+      {{- end }}
 </tr>
     {{- end }}
   {{- end }}
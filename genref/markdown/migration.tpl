@@ -0,0 +1,70 @@
+{{ define "migration" -}}
+---
+title: Migrating {{ .Title }} from {{ .From }} to {{ .To }}
+content_type: tool-reference
+auto_generated: true
+---
+
+This page lists the changes from `{{ .FromAPIVersion }}` to `{{ .ToAPIVersion }}`
+that need attention when migrating configuration files.
+
+{{ with .AddedKinds -}}
+## Added Kinds
+
+{{ range . -}}
+- `{{ . }}`
+{{ end }}
+{{ end -}}
+
+{{ with .RemovedKinds -}}
+## Removed Kinds
+
+{{ range . -}}
+- `{{ . }}`
+{{ end }}
+{{ end -}}
+
+{{ with .AddedTypes -}}
+## Added Types
+
+{{ range . -}}
+- `{{ . }}`
+{{ end }}
+{{ end -}}
+
+{{ with .RemovedTypes -}}
+## Removed Types
+
+{{ range . -}}
+- `{{ . }}`
+{{ end }}
+{{ end -}}
+
+{{ range .Types -}}
+## `{{ .Name }}`{{ if .IsKind }} (kind){{ end }}
+
+<table class="table">
+<thead><tr><th width="30%">Field</th><th width="20%">Change</th><th>Details</th></tr></thead>
+<tbody>
+  {{- range .Changes }}
+<tr><td><code>{{ .Field }}</code></td><td>{{ .Change }}</td><td>
+    {{- if eq .Change "added" -}}
+New field of type <code>{{ html .To }}</code>.
+    {{- else if eq .Change "removed" -}}
+Field of type <code>{{ html .From }}</code> is removed.
+    {{- else if eq .Change "renamed" -}}
+Renamed from <code>{{ .From }}</code>.
+    {{- else if eq .Change "retyped" -}}
+Type changed from <code>{{ html .From }}</code> to <code>{{ html .To }}</code>.
+    {{- else -}}
+Changed from {{ .From }} to {{ .To }}.
+    {{- end -}}
+</td></tr>
+  {{- end }}
+</tbody>
+</table>
+
+{{ else -}}
+No field changes are found for the types that exist in both versions.
+{{ end -}}
+{{- end }}
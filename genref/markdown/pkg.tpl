@@ -32,6 +32,14 @@ auto_generated: true
   {{- end -}}
 {{- end -}}
 
+{{ range .packages -}}
+  {{- with .Diagram }}
+
+```mermaid
+{{ . }}```
+  {{- end -}}
+{{- end -}}
+
 {{ range .packages }}
   {{ if ne .GroupName "" -}}
     {{/* For package with a group name, list all type definitions in it. */}}
@@ -15,18 +15,32 @@
 {{- end -}}
 {{- end }}
 
-{{ if .GetComment -}}
+{{ if or .Deprecated .FeatureGate .AddedIn .RemovedIn }}{{ template "badges" . }}
+
+{{ end }}{{ if .GetComment -}}
 {{ .GetComment }}
 {{ end }}
+{{- with .AllowedValues }}
+**Allowed values:**
+
+<table class="table">
+<thead><tr><th width="30%">Value</th><th>Description</th></tr></thead>
+<tbody>
+  {{- range . }}
+<tr><td><code>{{ with .Value }}{{ html . }}{{ else }}""{{ end }}</code></td><td>{{ .GetComment }}</td></tr>
+  {{- end }}
+</tbody>
+</table>
+{{ end }}
 {{ if .GetMembers -}}
 <table class="table">
-<thead><tr><th width="30%">Field</th><th>Description</th></tr></thead>
+<thead><tr><th width="30%">Field</th><th>Description</th>{{ if .HasConstraints }}<th>Constraints</th>{{ end }}</tr></thead>
 <tbody>
     {{/* . is a apiType */}}
     {{- if .IsExported -}}
 {{/* Add apiVersion and kind rows if deemed necessary */}}
-<tr><td><code>apiVersion</code><br/>string</td><td><code>{{- .APIGroup -}}</code></td></tr>
-<tr><td><code>kind</code><br/>string</td><td><code>{{- .Name.Name -}}</code></td></tr>
+<tr><td><code>apiVersion</code><br/>string</td><td{{ if .HasConstraints }} colspan="2"{{ end }}><code>{{- .APIGroup -}}</code></td></tr>
+<tr><td><code>kind</code><br/>string</td><td{{ if .HasConstraints }} colspan="2"{{ end }}><code>{{- .Name.Name -}}</code></td></tr>
     {{ end -}}
 
 {{/* The actual list of members is in the following template */}}
@@ -34,4 +48,11 @@
 </tbody>
 </table>
 {{- end -}}
+{{ with .Skeleton }}
+
+**Example:**
+
+```yaml
+{{ . }}```
+{{- end -}}
 {{- end -}}